@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/peermgr"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc/client"
+	logger "github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+)
+
+var peersCommand = cli.Command{
+	Name:  "peers",
+	Usage: "manages the peers of the node",
+	Subcommands: []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "lists the connected peers",
+			Action: listPeers,
+		},
+		{
+			Name:      "connect",
+			Usage:     "connects to a peer",
+			ArgsUsage: "<address>",
+			Action:    connectPeer,
+		},
+		{
+			Name:      "disconnect",
+			Usage:     "disconnects a peer",
+			ArgsUsage: "<address>",
+			Action:    disconnectPeer,
+		},
+		{
+			Name:      "ban",
+			Usage:     "bans a host, and disconnects its peers",
+			ArgsUsage: "<host>",
+			Flags:     []cli.Flag{durationFlag, reasonFlag},
+			Action:    banHost,
+		},
+		{
+			Name:      "unban",
+			Usage:     "lifts the ban of a host, or all of them if no host is given",
+			ArgsUsage: "[host]",
+			Action:    unbanHost,
+		},
+		{
+			Name:   "routes",
+			Usage:  "dumps the kadcast routing table",
+			Action: dumpRoutingTable,
+		},
+	},
+}
+
+func before(ctx *cli.Context) error {
+	if logLevel := ctx.GlobalString(LogLevelFlag.Name); logLevel != "" {
+		var err error
+		log.Logger.Level, err = logger.ParseLevel(logLevel)
+		if err != nil {
+			return fmt.Errorf("could not parse logLevel: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// withAdmin calls f with a client of the PeerAdmin service of the node,
+// within a session which is dropped once f returns
+func withAdmin(ctx *cli.Context, f func(context.Context, peermgr.PeerAdminClient) error) error {
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}
+	nc := client.New(ctx.GlobalString(networkFlag.Name), ctx.GlobalString(addressFlag.Name))
+	defer nc.GracefulClose(opts...)
+
+	conn, err := nc.GetSessionConn(opts...)
+	if err != nil {
+		return fmt.Errorf("could not open a session with the node: %v", err)
+	}
+
+	callCtx, cancel := context.WithTimeout(context.Background(), ctx.GlobalDuration(timeoutFlag.Name))
+	defer cancel()
+
+	return f(callCtx, peermgr.NewPeerAdminClient(conn))
+}
+
+// argument returns the single argument of a command
+func argument(ctx *cli.Context) (string, error) {
+	if ctx.NArg() != 1 {
+		return "", fmt.Errorf("expected one argument, got %d", ctx.NArg())
+	}
+
+	return ctx.Args().First(), nil
+}
+
+func listPeers(ctx *cli.Context) error {
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		list, err := admin.ListPeers(c, &peermgr.ListPeersRequest{})
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "ADDRESS\tDIRECTION\tVERSION\tSERVICES\tSINCE\tSTART HEIGHT\tHEIGHT\tBYTES IN\tBYTES OUT\tLATENCY")
+		for _, p := range list.Peers {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%d\t%d\t%d\t%d\t%s\n",
+				p.Address, p.Direction, p.Version, p.Services, p.Since.Format(time.RFC3339),
+				p.StartHeight, p.Height, p.BytesIn, p.BytesOut, p.Latency)
+		}
+
+		return w.Flush()
+	})
+}
+
+func connectPeer(ctx *cli.Context) error {
+	addr, err := argument(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		if _, err := admin.Connect(c, &peermgr.PeerRequest{Address: addr}); err != nil {
+			return err
+		}
+
+		fmt.Println("connected to", addr)
+		return nil
+	})
+}
+
+func disconnectPeer(ctx *cli.Context) error {
+	addr, err := argument(ctx)
+	if err != nil {
+		return err
+	}
+
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		if _, err := admin.Disconnect(c, &peermgr.PeerRequest{Address: addr}); err != nil {
+			return err
+		}
+
+		fmt.Println("disconnected from", addr)
+		return nil
+	})
+}
+
+func banHost(ctx *cli.Context) error {
+	host, err := argument(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := &peermgr.BanRequest{
+		Host:     host,
+		Duration: uint64(ctx.Duration(durationFlag.Name).Seconds()),
+		Reason:   ctx.String(reasonFlag.Name),
+	}
+
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		resp, err := admin.Ban(c, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("banned %s, %d peer(s) disconnected\n", host, resp.Disconnected)
+		return nil
+	})
+}
+
+func unbanHost(ctx *cli.Context) error {
+	if ctx.NArg() > 1 {
+		return fmt.Errorf("expected at most one argument, got %d", ctx.NArg())
+	}
+
+	host := ctx.Args().First()
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		resp, err := admin.Unban(c, &peermgr.UnbanRequest{Host: host})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%d ban(s) lifted\n", resp.Unbanned)
+		return nil
+	})
+}
+
+func dumpRoutingTable(ctx *cli.Context) error {
+	return withAdmin(ctx, func(c context.Context, admin peermgr.PeerAdminClient) error {
+		rt, err := admin.GetRoutingTable(c, &peermgr.RoutingTableRequest{})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("local peer: %s %s\n", rt.LocalPeer.Address, rt.LocalPeer.ID)
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "BUCKET\tADDRESS\tID")
+		for _, b := range rt.Buckets {
+			for _, p := range b.Peers {
+				_, _ = fmt.Fprintf(w, "%d\t%s\t%s\n", b.Index, p.Address, p.ID)
+			}
+		}
+
+		return w.Flush()
+	})
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"time"
+
+	"github.com/urfave/cli"
+)
+
+var (
+	// LogLevelFlag flag to set log level
+	LogLevelFlag = cli.StringFlag{
+		Name:  "loglevel",
+		Usage: "log level, eg: (warn, error, fatal, panic)",
+		Value: "warn",
+	}
+	networkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: "network of the gRPC server of the node, eg: --network=unix",
+		Value: "unix",
+	}
+	addressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "address of the gRPC server of the node, eg: --address=/tmp/dusk-grpc.sock",
+		Value: "/tmp/dusk-grpc.sock",
+	}
+	timeoutFlag = cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "timeout of the calls to the node, eg: --timeout=10s",
+		Value: 10 * time.Second,
+	}
+
+	durationFlag = cli.DurationFlag{
+		Name:  "duration",
+		Usage: "duration of the ban, the node default applies if zero, eg: --duration=24h",
+	}
+	reasonFlag = cli.StringFlag{
+		Name:  "reason",
+		Usage: "reason of the ban, eg: --reason=spam",
+	}
+)
+
+var (
+	// CLIFlags flags usable in a CLI context
+	CLIFlags = []cli.Flag{
+		LogLevelFlag,
+		networkFlag,
+		addressFlag,
+		timeoutFlag,
+	}
+)
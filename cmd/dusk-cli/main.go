@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var (
+	log *logrus.Entry
+	app = cli.NewApp()
+)
+
+func initLog() {
+	log = logrus.WithFields(logrus.Fields{
+		"app":    "dusk-cli",
+		"prefix": "main",
+	})
+}
+
+func init() {
+	initLog()
+
+	app.Copyright = "Copyright (c) 2020 DUSK"
+	app.Name = "dusk-cli"
+	app.Usage = "Administers a running Dusk node through its gRPC server"
+	app.Author = "DUSK 2020"
+	app.Version = "0.0.1"
+	app.Before = before
+	app.Commands = []cli.Command{peersCommand}
+	app.Flags = append(app.Flags, CLIFlags...)
+}
+
+func main() {
+	defer handlePanic()
+
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func handlePanic() {
+	if r := recover(); r != nil {
+		log.WithError(fmt.Errorf("%+v", r)).Errorln("Application dusk-cli panic")
+	}
+	time.Sleep(time.Second * 1)
+}
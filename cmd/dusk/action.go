@@ -115,12 +115,9 @@ func action(ctx *cli.Context) error {
 	// fetch neighbors addresses from the Voucher
 	ips := ConnectToVoucher()
 
-	// trying to connect to the peers
-	for _, ip := range ips {
-		if err := connMgr.Connect(ip); err != nil {
-			log.WithField("IP", ip).Warnln(err)
-		}
-	}
+	// the peer manager keeps connecting to the peers, and reconnects to the
+	// dropped ones
+	srv.peers.Start(connMgr.Connect, ips)
 
 	log.Info("initialization complete")
 
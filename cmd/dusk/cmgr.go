@@ -9,7 +9,7 @@ import (
 type CmgrConfig struct {
 	Port     string
 	OnAccept func(net.Conn)
-	OnConn   func(net.Conn, string) // takes the connection  and the string
+	OnConn   func(net.Conn, string) error // takes the connection  and the string
 }
 
 type connmgr struct {
@@ -51,7 +51,8 @@ func newConnMgr(cfg CmgrConfig) *connmgr {
 }
 
 // Connect dials a connection with its string, then on succession
-// we pass the connection and the address to the OnConn method
+// we pass the connection and the address to the OnConn method. It returns
+// once OnConn established the connection, or failed to
 func (c *connmgr) Connect(addr string) error {
 	conn, err := c.Dial(addr)
 	if err != nil {
@@ -59,7 +60,7 @@ func (c *connmgr) Connect(addr string) error {
 	}
 
 	if c.CmgrConfig.OnConn != nil {
-		go c.CmgrConfig.OnConn(conn, addr)
+		return c.CmgrConfig.OnConn(conn, addr)
 	}
 
 	return nil
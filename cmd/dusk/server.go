@@ -12,6 +12,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/chain"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakemanager"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -23,7 +24,9 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/peermgr"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/rpc/client"
@@ -40,32 +43,28 @@ var logServer = logrus.WithField("process", "server")
 
 // Server is the main process of the node
 type Server struct {
-	eventBus          *eventbus.EventBus
-	rpcBus            *rpcbus.RPCBus
-	loader            chain.Loader
-	dupeMap           *dupemap.DupeMap
-	gossip            *protocol.Gossip
-	grpcServer        *grpc.Server
-	ruskConn          *grpc.ClientConn
-	readerFactory     *peer.ReaderFactory
-	activeConnections map[string]time.Time
-	kadPeer           *kadcast.Peer
+	eventBus      *eventbus.EventBus
+	rpcBus        *rpcbus.RPCBus
+	loader        chain.Loader
+	dupeMap       *dupemap.DupeMap
+	gossip        *protocol.Gossip
+	grpcServer    *grpc.Server
+	ruskConn      *grpc.ClientConn
+	readerFactory *peer.ReaderFactory
+	peers         *peermgr.Manager
+	lightChain    *chain.LightChain
+	kadPeer       *kadcast.Peer
+	mempool       *mempool.Mempool
+	stakeStore    *stakemanager.Store
 }
 
 // LaunchChain instantiates a chain.Loader, does the wire up to create a Chain
 // component and performs a DB sanity check
 func LaunchChain(ctx context.Context, proxy transactions.Proxy, eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, srv *grpc.Server, db database.DB, requestor *candidate.Requestor) (chain.Loader, peer.ProcessorFunc, func(keys.PublicKey, key.Keys) error, error) {
 	// creating and firing up the chain process
-	var genesis *block.Block
-	if cfg.Get().Genesis.Legacy {
-		g := legacy.DecodeGenesis()
-		var err error
-		genesis, err = legacy.OldBlockToNewBlock(g)
-		if err != nil {
-			return nil, nil, nil, err
-		}
-	} else {
-		genesis = cfg.DecodeGenesis()
+	genesis, err := genesisBlock()
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	l := chain.NewDBLoader(db, genesis)
 
@@ -83,6 +82,36 @@ func LaunchChain(ctx context.Context, proxy transactions.Proxy, eventBus *eventb
 	return l, chainProcess.ProcessBlock, chainProcess.SetupConsensus, nil
 }
 
+// LaunchLightChain instantiates a chain.Loader and a chain.LightChain, which
+// syncs the headers from the trusted checkpoint
+func LaunchLightChain(eventBus *eventbus.EventBus, db database.DB) (chain.Loader, *chain.LightChain, error) {
+	genesis, err := genesisBlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	l := chain.NewDBLoader(db, genesis)
+
+	checkpoint, err := chain.LoadCheckpoint(chain.CheckpointFile())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lightChain, err := chain.NewLightChain(db, eventBus, l, checkpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return l, lightChain, nil
+}
+
+func genesisBlock() (*block.Block, error) {
+	if cfg.Get().Genesis.Legacy {
+		return legacy.OldBlockToNewBlock(legacy.DecodeGenesis())
+	}
+
+	return cfg.DecodeGenesis(), nil
+}
+
 func (s *Server) launchKadcastPeer() {
 
 	kcfg := cfg.Get().Kadcast
@@ -119,24 +148,34 @@ func Setup() *Server {
 	processor := peer.NewMessageProcessor(eventBus)
 	// Register peer services
 	processor.Register(topics.Ping, responding.ProcessPing)
-	dataBroker := responding.NewDataBroker(db, rpcBus)
-	processor.Register(topics.GetData, dataBroker.SendItems)
-	dataRequestor := responding.NewDataRequestor(db, rpcBus, eventBus)
-	processor.Register(topics.Inv, dataRequestor.RequestMissingItems)
-	bhb := responding.NewBlockHashBroker(db)
-	processor.Register(topics.GetBlocks, bhb.AdvertiseMissingBlocks)
-	cb := responding.NewCandidateBroker(db)
-	processor.Register(topics.GetCandidate, cb.ProvideCandidate)
-	cr := candidate.NewRequestor(eventBus)
-	processor.Register(topics.Candidate, cr.ProcessCandidate)
-	cp := consensus.NewPublisher(eventBus)
-	processor.Register(topics.Score, cp.Process)
-	processor.Register(topics.Reduction, cp.Process)
-	processor.Register(topics.Agreement, cp.Process)
 
-	_ = republisher.New(eventBus, topics.Score)
-	_ = republisher.New(eventBus, topics.Reduction)
-	_ = republisher.New(eventBus, topics.Agreement)
+	// light nodes neither hold the blocks nor take part in the consensus, so
+	// that they only register the services to sync the headers
+	light := cfg.Get().Light.Enabled
+	cr := candidate.NewRequestor(eventBus)
+	if !light {
+		dataBroker := responding.NewDataBroker(db, rpcBus)
+		processor.Register(topics.GetData, dataBroker.SendItems)
+		dataRequestor := responding.NewDataRequestor(db, rpcBus, eventBus)
+		processor.Register(topics.Inv, dataRequestor.RequestMissingItems)
+		bhb := responding.NewBlockHashBroker(db)
+		processor.Register(topics.GetBlocks, bhb.AdvertiseMissingBlocks)
+		hb := responding.NewHeaderBroker(db)
+		processor.Register(topics.GetHeaders, hb.ProvideHeaders)
+		mb := responding.NewMempoolBroker(rpcBus)
+		processor.Register(topics.MemPool, mb.AdvertiseMempool)
+		cb := responding.NewCandidateBroker(db)
+		processor.Register(topics.GetCandidate, cb.ProvideCandidate)
+		processor.Register(topics.Candidate, cr.ProcessCandidate)
+		cp := consensus.NewPublisher(eventBus)
+		processor.Register(topics.Score, cp.Process)
+		processor.Register(topics.Reduction, cp.Process)
+		processor.Register(topics.Agreement, cp.Process)
+
+		_ = republisher.New(eventBus, topics.Score)
+		_ = republisher.New(eventBus, topics.Reduction)
+		_ = republisher.New(eventBus, topics.Agreement)
+	}
 
 	// Instantiate gRPC client
 	// TODO: get address from config
@@ -151,9 +190,13 @@ func Setup() *Server {
 	defaultTimeout := time.Duration(cfg.Get().RPC.Rusk.DefaultTimeout) * time.Millisecond
 	proxy := transactions.NewProxy(ruskClient, keysClient, blindbidServiceClient, bidServiceClient, transferClient, stakeClient, txTimeout, defaultTimeout)
 
+	// the mempool of the light nodes only propagates the transactions of the
+	// wallet
 	m := mempool.NewMempool(ctx, eventBus, rpcBus, proxy.Prober(), grpcServer)
 	m.Run()
-	processor.Register(topics.Tx, m.ProcessTx)
+	if !light {
+		processor.RegisterPeer(topics.Tx, m.ProcessTx)
+	}
 
 	// Instantiate API server
 	if cfg.Get().API.Enabled {
@@ -168,12 +211,34 @@ func Setup() *Server {
 		}
 	}
 
-	chainDBLoader, blkFn, consFn, err := LaunchChain(ctx, proxy, eventBus, rpcBus, grpcServer, db, cr)
-	if err != nil {
-		log.Panic(err)
-	}
+	var chainDBLoader chain.Loader
+	var lightChain *chain.LightChain
+	var consFn func(keys.PublicKey, key.Keys) error
+	if light {
+		chainDBLoader, lightChain, err = LaunchLightChain(eventBus, db)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		processor.Register(topics.Headers, lightChain.ProcessHeaders)
+		processor.Register(topics.Inv, lightChain.ProcessInv)
+		processor.Register(topics.Block, lightChain.ProcessBlock)
+		processor.Register(topics.Tx, lightChain.ProcessTx)
+		peer.SetLightNode(true)
+
+		consFn = func(keys.PublicKey, key.Keys) error {
+			log.Warn("consensus is not available on light nodes")
+			return nil
+		}
+	} else {
+		var blkFn peer.ProcessorFunc
+		chainDBLoader, blkFn, consFn, err = LaunchChain(ctx, proxy, eventBus, rpcBus, grpcServer, db, cr)
+		if err != nil {
+			log.Panic(err)
+		}
 
-	processor.Register(topics.Block, blkFn)
+		processor.Register(topics.Block, blkFn)
+	}
 
 	// Setting up a dupemap
 	dupeBlacklist := dupemap.Launch(eventBus)
@@ -192,17 +257,87 @@ func Setup() *Server {
 	// Creating the peer factory
 	readerFactory := peer.NewReaderFactory(processor)
 
+	// Enabling the encrypted transport, authenticated by the identity key
+	// persisted across restarts
+	if transport := cfg.Get().Network.Transport; transport.Encryption || transport.Required {
+		identity, err := peer.LoadIdentity(peer.IdentityFile())
+		if err != nil {
+			log.Panic(err)
+		}
+
+		peer.SetTransport(identity, transport.Required)
+	}
+
+	// Compressing the large messages sent to the peers supporting it
+	if transport := cfg.Get().Network.Transport; transport.Compression {
+		threshold := transport.CompressionThreshold
+		if threshold <= 0 {
+			threshold = peer.DefaultCompressionThreshold
+		}
+
+		peer.SetCompression(threshold)
+	}
+
+	// Limiting the traffic received from each peer
+	limits := cfg.Get().Network.Limits
+	rateLimits := peer.RateLimits{
+		PeerBytes: float64(limits.PeerBytes),
+		Topics:    make(map[topics.Topic]float64),
+	}
+
+	for name, rate := range limits.Topics {
+		topic := topics.StringToTopic(name)
+		if topic == topics.Unknown {
+			log.WithField("topic", name).Warnln("rate limit of an unknown topic")
+			continue
+		}
+
+		rateLimits.Topics[topic] = rate
+	}
+
+	peer.SetRateLimits(rateLimits)
+
+	// Creating the peer manager, which dials the known good addresses of the
+	// previous runs along with the ones provided by the voucher
+	addrBook, err := peermgr.LoadAddrBook(peermgr.AddrBookFile())
+	if err != nil {
+		log.Panic(err)
+	}
+
+	banList, err := peermgr.LoadBanList(peermgr.BanListFile())
+	if err != nil {
+		log.Panic(err)
+	}
+
+	// the peer manager scores the misbehaviours reported by the readers, and
+	// exchanges addresses with the peers
+	peers := peermgr.New(peermgr.ConfigFromFile(), addrBook, banList, rpcBus)
+	readerFactory.SetReputation(peers)
+	m.SetReputation(peers)
+	processor.RegisterPeer(topics.GetAddr, peers.ProcessGetAddr)
+	processor.RegisterPeer(topics.Addr, peers.ProcessAddr)
+
 	// creating the Server
 	srv := &Server{
-		eventBus:          eventBus,
-		rpcBus:            rpcBus,
-		loader:            chainDBLoader,
-		dupeMap:           dupeBlacklist,
-		gossip:            protocol.NewGossip(protocol.TestNet),
-		grpcServer:        grpcServer,
-		ruskConn:          ruskConn,
-		readerFactory:     readerFactory,
-		activeConnections: make(map[string]time.Time),
+		eventBus:      eventBus,
+		rpcBus:        rpcBus,
+		loader:        chainDBLoader,
+		dupeMap:       dupeBlacklist,
+		gossip:        protocol.NewGossip(protocol.TestNet),
+		grpcServer:    grpcServer,
+		ruskConn:      ruskConn,
+		readerFactory: readerFactory,
+		peers:         peers,
+		lightChain:    lightChain,
+		mempool:       m,
+	}
+
+	// Setting up the stake manager, which starts along with the consensus
+	if cfg.Get().Consensus.StakeManager.Enabled && !light {
+		consFn, srv.stakeStore, err = launchStakeManager(eventBus, rpcBus, proxy, consFn)
+		if err != nil {
+			log.Panic(err)
+		}
 	}
 
 	// Setting up the transactor component
@@ -211,11 +346,34 @@ func Setup() *Server {
 		log.Panic(err)
 	}
 
-	// TODO: maintainer should be started here
+	// Advertising the height of the chain in the version messages sent to
+	// the peers
+	if tip, err := chainDBLoader.LoadTip(); err == nil {
+		peer.SetLocalHeight(tip.Header.Height)
+	}
+
+	eventBus.Subscribe(topics.AcceptedBlock, eventbus.NewCallbackListener(func(m message.Message) {
+		if blk, ok := m.Payload().(block.Block); ok {
+			peer.SetLocalHeight(blk.Header.Height)
+		}
+	}))
 
 	// Setting up and launch kadcast peer
 	srv.launchKadcastPeer()
 
+	// Setting up the administration of the peers, which requires the
+	// session authentication
+	if cfg.Get().RPC.RequireSession {
+		var router *kadcast.RoutingTable
+		if srv.kadPeer != nil {
+			router = srv.kadPeer.RoutingTable()
+		}
+
+		peermgr.RegisterPeerAdminServer(grpcServer, peermgr.NewAdmin(peers, router))
+	} else {
+		log.Warn("peer administration is disabled, as it requires the RPC session authentication")
+	}
+
 	// Start serving from the gRPC server
 	go func() {
 		conf := cfg.Get().RPC
@@ -235,8 +393,30 @@ func Setup() *Server {
 	return srv
 }
 
+// launchStakeManager creates a stakemanager.Manager and wraps the consensus
+// setup function, so that the Manager starts with the keys of the wallet
+func launchStakeManager(eventBus *eventbus.EventBus, rpcBus *rpcbus.RPCBus, proxy transactions.Proxy, setupConsensus func(keys.PublicKey, key.Keys) error) (func(keys.PublicKey, key.Keys) error, *stakemanager.Store, error) {
+	store, err := stakemanager.OpenStore(stakemanager.StoreDir())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := stakemanager.New(eventBus, rpcBus, proxy.Executor(), store, stakemanager.PolicyFromConfig())
+	return func(pk keys.PublicKey, blsKeys key.Keys) error {
+		m.Start(blsKeys.BLSPubKeyBytes)
+		return setupConsensus(pk, blsKeys)
+	}, store, nil
+}
+
 // OnAccept read incoming packet from the peers
 func (s *Server) OnAccept(conn net.Conn) {
+	addr := conn.RemoteAddr().String()
+	if !s.peers.AllowInbound(addr) {
+		logServer.WithField("address", addr).Debugln("OnAccept, inbound connection refused")
+		_ = conn.Close()
+		return
+	}
+
 	writeQueueChan := make(chan bytes.Buffer, 1000)
 	peerReader, err := s.readerFactory.SpawnReader(conn, s.gossip, s.dupeMap, writeQueueChan)
 	if err != nil {
@@ -247,36 +427,84 @@ func (s *Server) OnAccept(conn net.Conn) {
 		logServer.WithError(err).Warnln("OnAccept, problem performing handshake")
 		return
 	}
+
+	if err := s.peers.Connected(addr, peermgr.HandshakeOf(peerReader.Connection), peermgr.Inbound); err != nil {
+		logServer.WithError(err).WithField("address", addr).Debugln("OnAccept, connection refused")
+		_ = conn.Close()
+		return
+	}
 	logServer.WithField("address", peerReader.Addr()).Debugln("connection established")
 
-	peerWriter := peer.NewWriter(conn, s.gossip, s.eventBus)
-	go peer.Create(context.Background(), peerReader, peerWriter, writeQueueChan)
+	// the handshake may have upgraded the connection to the encrypted transport
+	peerWriter := peer.NewWriter(peerReader.Conn, s.gossip, s.eventBus)
+	peerWriter.Inherit(peerReader.Connection)
+	go func() {
+		peer.Create(context.Background(), peerReader, peerWriter, writeQueueChan)
+		s.peers.Disconnected(addr)
+	}()
 }
 
-// OnConnection is the callback for writing to the peers
-func (s *Server) OnConnection(conn net.Conn, addr string) {
+// OnConnection is the callback for writing to the peers. It returns once the
+// handshake is completed
+func (s *Server) OnConnection(conn net.Conn, addr string) error {
 	writeQueueChan := make(chan bytes.Buffer, 1000)
 	peerWriter := peer.NewWriter(conn, s.gossip, s.eventBus)
 
 	if err := peerWriter.Connect(); err != nil {
 		logServer.WithError(err).Warnln("OnConnection, problem performing handshake")
-		return
+		return err
+	}
+
+	if err := s.peers.Connected(addr, peermgr.HandshakeOf(peerWriter.Connection), peermgr.Outbound); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	// asking each outbound peer for addresses keeps the address book filled
+	// when the voucher is not available
+	getAddr, err := peermgr.MarshalGetAddr()
+	if err != nil {
+		log.Panic(err)
 	}
+	writeQueueChan <- getAddr
+
+	// light nodes sync the headers from each full node they connect to
+	if s.lightChain != nil && peerWriter.RemoteServices()&protocol.FullNode != 0 {
+		getHeaders, err := s.lightChain.MarshalGetHeaders()
+		if err != nil {
+			log.Panic(err)
+		}
+		writeQueueChan <- getHeaders
+	}
+
 	address := peerWriter.Addr()
 	logServer.WithField("address", address).
 		Debugln("connection established")
 
-	peerReader, err := s.readerFactory.SpawnReader(conn, s.gossip, s.dupeMap, writeQueueChan)
+	// the handshake may have upgraded the connection to the encrypted transport
+	peerReader, err := s.readerFactory.SpawnReader(peerWriter.Conn, s.gossip, s.dupeMap, writeQueueChan)
 	if err != nil {
 		log.Panic(err)
 	}
+	peerReader.Inherit(peerWriter.Connection)
 
-	go peer.Create(context.Background(), peerReader, peerWriter, writeQueueChan)
+	go func() {
+		peer.Create(context.Background(), peerReader, peerWriter, writeQueueChan)
+		s.peers.Disconnected(addr)
+	}()
+	return nil
 }
 
 // Close the chain and the connections created through the RPC bus
 func (s *Server) Close() {
 	// TODO: disconnect peers
+	// stopping the peer manager persists the address book
+	if err := s.peers.Stop(); err != nil {
+		logServer.WithError(err).Warnln("could not save the address book")
+	}
+
+	// stopping the mempool dumps it, if persistence is enabled
+	s.mempool.Quit()
 	_ = s.loader.Close(cfg.Get().Database.Driver)
 	s.rpcBus.Close()
 	s.grpcServer.GracefulStop()
@@ -285,4 +513,8 @@ func (s *Server) Close() {
 	if s.kadPeer != nil {
 		s.kadPeer.Close()
 	}
+
+	if s.stakeStore != nil {
+		_ = s.stakeStore.Close()
+	}
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	logger "github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+)
+
+// netPrefix is the network prefix of the wallet. It plays no role in the
+// derivation of the consensus keys
+var netPrefix = byte(2)
+
+func action(ctx *cli.Context) error {
+
+	// check arguments
+	if arguments := ctx.Args(); len(arguments) > 0 {
+		return fmt.Errorf("failed to read command argument: %q", arguments[0])
+	}
+
+	if logLevel := ctx.GlobalString(LogLevelFlag.Name); logLevel != "" {
+		var err error
+		log.Logger.Level, err = logger.ParseLevel(logLevel)
+		if err != nil {
+			log.WithError(err).Fatal("could not parse logLevel")
+		}
+	}
+
+	network := ctx.String(networkFlag.Name)
+	address := ctx.String(addressFlag.Name)
+	token := ctx.String(tokenFlag.Name)
+	if signer.RequiresToken(network) && token == "" {
+		return signer.ErrTokenRequired
+	}
+
+	// only the consensus keys are needed, so the wallet database is not
+	// opened
+	w, err := wallet.LoadFromFile(netPrefix, nil, ctx.String(passwordFlag.Name), ctx.String(walletFileFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	record, err := signer.OpenRecord(ctx.String(recordFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = record.Close()
+	}()
+
+	if network == "unix" {
+		// remove the socket left over by a previous run
+		_ = os.Remove(address)
+	}
+
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+
+	if network == "unix" {
+		// only the node user should be able to request signatures
+		if err := os.Chmod(address, 0600); err != nil {
+			return err
+		}
+	}
+
+	var opts []grpc.ServerOption
+	if token != "" {
+		opts = append(opts, grpc.UnaryInterceptor(signer.TokenInterceptor(token)))
+	}
+
+	srv := grpc.NewServer(opts...)
+	signer.RegisterSignerServer(srv, signer.NewService(w.Keys(), record))
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		srv.GracefulStop()
+	}()
+
+	log.
+		WithField("network", network).
+		WithField("address", address).
+		Info("dusk-signer up & accepting connections")
+	return srv.Serve(l)
+}
@@ -0,0 +1,55 @@
+package main
+
+import "github.com/urfave/cli"
+
+var (
+	// LogLevelFlag flag to set log level
+	LogLevelFlag = cli.StringFlag{
+		Name:  "loglevel",
+		Usage: "log level, eg: (warn, error, fatal, panic)",
+		Value: "info",
+	}
+	networkFlag = cli.StringFlag{
+		Name:  "network",
+		Usage: "network to listen on, eg: --network=unix",
+		Value: "unix",
+	}
+	addressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "address to listen on, eg: --address=/tmp/dusk-signer.sock",
+		Value: "/tmp/dusk-signer.sock",
+	}
+	walletFileFlag = cli.StringFlag{
+		Name:  "walletfile",
+		Usage: "wallet file holding the consensus keys, eg: --walletfile=wallet.dat",
+		Value: "wallet.dat",
+	}
+	passwordFlag = cli.StringFlag{
+		Name:   "password",
+		Usage:  "password of the wallet file",
+		EnvVar: "DUSK_WALLET_PASS",
+	}
+	tokenFlag = cli.StringFlag{
+		Name:   "token",
+		Usage:  "token shared with the node, required on any network but unix",
+		EnvVar: "DUSK_SIGNER_TOKEN",
+	}
+	recordFlag = cli.StringFlag{
+		Name:  "record",
+		Usage: "directory of the record of signed rounds and steps, eg: --record=signer.db",
+		Value: "signer.db",
+	}
+)
+
+var (
+	// CLIFlags flags usable in a CLI context
+	CLIFlags = []cli.Flag{
+		LogLevelFlag,
+		networkFlag,
+		addressFlag,
+		walletFileFlag,
+		passwordFlag,
+		tokenFlag,
+		recordFlag,
+	}
+)
@@ -265,3 +265,25 @@ func getBlocksCountQuery(client *graphql.Client, values map[string]interface{})
 
 	return executeQuery(client, query, target, values)
 }
+
+func getBandwidthStats(duskInfo *DuskInfo) (*BandwidthStats, error) {
+	//nolint:gosec
+	resp, err := http.Get(duskInfo.NodeAPIEndpoint + "/p2p/bandwidth")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	stats := new(BandwidthStats)
+	if err := json.NewDecoder(resp.Body).Decode(stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
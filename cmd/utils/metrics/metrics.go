@@ -40,6 +40,7 @@ type DuskInfo struct {
 	GQLEndpoint        string
 	GQLClient          *graphql.Client
 	NodeAPIPort        int
+	NodeAPIEndpoint    string
 }
 
 // RunMetrics will run the metrics collection endpoint
@@ -47,6 +48,7 @@ func RunMetrics(gqlPort, nodePort, nodeAPIPort, port int, hostname string) {
 	duskInfo = new(DuskInfo)
 	duskInfo.TotalDusk = big.NewInt(0)
 	duskInfo.NodeAPIPort = nodeAPIPort
+	duskInfo.NodeAPIEndpoint = "http://127.0.0.1:" + strconv.Itoa(nodeAPIPort)
 
 	node = engine.NewDuskNode(gqlPort, nodePort, "default", localNet.IsSessionRequired())
 	localNet.AddNode(node)
@@ -171,9 +173,39 @@ func HandlerMetrics(w http.ResponseWriter, r *http.Request) {
 	allOut = append(allOut, fmt.Sprintf("dusk_transfers %v", duskInfo.DuskTransfers))
 	allOut = append(allOut, fmt.Sprintf("dusk_load_time %0.4f", duskInfo.LoadTime))
 
+	bandwidth, err := getBandwidthStats(duskInfo)
+	if err != nil {
+		fmt.Printf("ERROR: getBandwidthStats: %+v\n", err)
+	} else {
+		allOut = append(allOut, bandwidthMetrics(bandwidth)...)
+	}
+
 	_, _ = fmt.Fprintln(w, strings.Join(allOut, "\n"))
 }
 
+// bandwidthMetrics returns the traffic of the node by topic and direction,
+// and by connected peer
+func bandwidthMetrics(stats *BandwidthStats) []string {
+	var out []string
+	for topic, t := range stats.Topics {
+		out = append(out, fmt.Sprintf("dusk_p2p_messages{topic=%q,direction=\"sent\"} %d", topic, t.Sent.Messages))
+		out = append(out, fmt.Sprintf("dusk_p2p_messages{topic=%q,direction=\"received\"} %d", topic, t.Received.Messages))
+		out = append(out, fmt.Sprintf("dusk_p2p_bytes{topic=%q,direction=\"sent\"} %d", topic, t.Sent.Bytes))
+		out = append(out, fmt.Sprintf("dusk_p2p_bytes{topic=%q,direction=\"received\"} %d", topic, t.Received.Bytes))
+	}
+
+	for addr, p := range stats.Peers {
+		out = append(out, fmt.Sprintf("dusk_p2p_peer_bytes{peer=%q,direction=\"sent\"} %d", addr, p.Sent.Bytes))
+		out = append(out, fmt.Sprintf("dusk_p2p_peer_bytes{peer=%q,direction=\"received\"} %d", addr, p.Received.Bytes))
+		out = append(out, fmt.Sprintf("dusk_p2p_peer_throttled{peer=%q} %d", addr, p.Throttled))
+		out = append(out, fmt.Sprintf("dusk_p2p_peer_dropped{peer=%q} %d", addr, p.Dropped))
+	}
+
+	out = append(out, fmt.Sprintf("dusk_p2p_throttled %d", stats.Throttled))
+	out = append(out, fmt.Sprintf("dusk_p2p_dropped %d", stats.Dropped))
+	return out
+}
+
 // CalculateTotals will calculate totals for a block
 func CalculateTotals(block *Block) {
 	duskInfo.TotalDusk = big.NewInt(0)
@@ -33,3 +33,31 @@ type Header struct {
 	//*Certificate `json:"certificate"` // Block certificate
 	Hash []byte `json:"hash"` // Hash of all previous fields
 }
+
+// Traffic counts the messages exchanged with the peers, and their size
+type Traffic struct {
+	Messages uint64 `json:"messages"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+// TopicTraffic is the traffic of a topic in both directions
+type TopicTraffic struct {
+	Sent     Traffic `json:"sent"`
+	Received Traffic `json:"received"`
+}
+
+// PeerBandwidth is the traffic exchanged with a connected peer
+type PeerBandwidth struct {
+	Sent      Traffic `json:"sent"`
+	Received  Traffic `json:"received"`
+	Throttled uint64  `json:"throttled"`
+	Dropped   uint64  `json:"dropped"`
+}
+
+// BandwidthStats is the traffic of the node, as returned by /p2p/bandwidth
+type BandwidthStats struct {
+	Throttled uint64                   `json:"throttled"`
+	Dropped   uint64                   `json:"dropped"`
+	Topics    map[string]TopicTraffic  `json:"topics"`
+	Peers     map[string]PeerBandwidth `json:"peers"`
+}
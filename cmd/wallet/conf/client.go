@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -19,6 +20,7 @@ type NodeClient struct {
 	BlockGeneratorClient node.BlockGeneratorClient
 	ChainClient          node.ChainClient
 	MempoolClient        node.MempoolClient
+	FeeEstimatorClient   mempool.FeeEstimatorClient
 	conn                 *grpc.ClientConn
 }
 
@@ -96,6 +98,7 @@ func (c *NodeClient) Connect(conf rpcConfiguration) error {
 	c.BlockGeneratorClient = node.NewBlockGeneratorClient(conn)
 	c.ChainClient = node.NewChainClient(conn)
 	c.MempoolClient = node.NewMempoolClient(conn)
+	c.FeeEstimatorClient = mempool.NewFeeEstimatorClient(conn)
 
 	return nil
 }
@@ -73,21 +73,21 @@ func WalletMenu(client *conf.NodeClient) error {
 		var res string
 		switch result {
 		case "Transfer DUSK":
-			resp, err := transferDusk(client.TransactorClient)
+			resp, err := transferDusk(client.TransactorClient, client.FeeEstimatorClient)
 			if err != nil {
 				return err
 			}
 
 			res = "Tx hash: " + hex.EncodeToString(resp.Hash)
 		case "Stake DUSK":
-			resp, err := stakeDusk(client.TransactorClient)
+			resp, err := stakeDusk(client.TransactorClient, client.FeeEstimatorClient)
 			if err != nil {
 				return err
 			}
 
 			res = "Tx hash: " + hex.EncodeToString(resp.Hash)
 		case "Bid DUSK":
-			resp, err := bidDusk(client.TransactorClient)
+			resp, err := bidDusk(client.TransactorClient, client.FeeEstimatorClient)
 			if err != nil {
 				return err
 			}
@@ -5,11 +5,12 @@ import (
 	"strconv"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
 	"github.com/manifoldco/promptui"
 )
 
-func transferDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
+func transferDusk(client node.TransactorClient, estimator mempool.FeeEstimatorClient) (*node.TransactionResponse, error) {
 	amount := getAmount()
 
 	// FIXME: 493 - there should be syntax-validation of address
@@ -34,21 +35,22 @@ func transferDusk(client node.TransactorClient) (*node.TransactionResponse, erro
 		return nil, err
 	}
 
-	return client.Transfer(context.Background(), &node.TransferRequest{Amount: amount, Address: []byte(address)})
+	fee := getFee(estimator)
+	return client.Transfer(context.Background(), &node.TransferRequest{Amount: amount, Address: []byte(address), Fee: fee})
 }
 
-func bidDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
+func bidDusk(client node.TransactorClient, estimator mempool.FeeEstimatorClient) (*node.TransactionResponse, error) {
 	amount := getAmount()
 	lockTime := getLockTime()
-	// TODO: parameterize fee
-	return client.Bid(context.Background(), &node.BidRequest{Amount: amount, Fee: 100, Locktime: lockTime})
+	fee := getFee(estimator)
+	return client.Bid(context.Background(), &node.BidRequest{Amount: amount, Fee: fee, Locktime: lockTime})
 }
 
-func stakeDusk(client node.TransactorClient) (*node.TransactionResponse, error) {
+func stakeDusk(client node.TransactorClient, estimator mempool.FeeEstimatorClient) (*node.TransactionResponse, error) {
 	amount := getAmount()
 	lockTime := getLockTime()
-	// TODO: parameterize fee
-	return client.Stake(context.Background(), &node.StakeRequest{Amount: amount, Fee: 100, Locktime: lockTime})
+	fee := getFee(estimator)
+	return client.Stake(context.Background(), &node.StakeRequest{Amount: amount, Fee: fee, Locktime: lockTime})
 }
 
 func getAmount() uint64 {
@@ -97,3 +99,38 @@ func getLockTime() uint64 {
 	lockTime, _ := strconv.Atoi(lockTimeString)
 	return uint64(lockTime)
 }
+
+// defaultFee is suggested when the node can not estimate the fee
+const defaultFee = 100
+
+// getFee prompts for the fee, suggesting the one the node estimates for the
+// inclusion in the next block
+func getFee(estimator mempool.FeeEstimatorClient) uint64 {
+	suggested := uint64(defaultFee)
+	if e, err := estimator.EstimateFee(context.Background(), &mempool.EstimateFeeRequest{TargetBlocks: 1}); err == nil {
+		suggested = e.Fee
+	}
+
+	validate := func(input string) error {
+		if _, err := strconv.ParseUint(input, 10, 64); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label:     "Fee",
+		Validate:  validate,
+		Default:   strconv.FormatUint(suggested, 10),
+		AllowEdit: true,
+	}
+
+	feeString, err := prompt.Run()
+	if err != nil {
+		panic(err)
+	}
+
+	fee, _ := strconv.ParseUint(feeString, 10, 64)
+	return fee
+}
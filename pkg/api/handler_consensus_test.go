@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -124,6 +125,50 @@ func TestConsensusAPIProvisioners(t *testing.T) {
 	})
 }
 
+func TestConsensusAPIBidders(t *testing.T) {
+
+	//setup viper timeout
+	cwd, err := os.Getwd()
+	require.Nil(t, err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../dusk.toml")
+	require.Nil(t, err)
+	cfg.Mock(&r)
+
+	apiServer, err := NewHTTPServer(nil, nil)
+	require.Nil(t, err)
+
+	var bids []capi.Bid
+	for i := 0; i < 5; i++ {
+		bids = append(bids, capi.Bid{
+			TxID:   []byte{byte(i)},
+			Amount: uint64(100 + i),
+			Fee:    1,
+		})
+	}
+
+	err = apiServer.store.StoreBidders(2, bids, &capi.OwnBid{D: []byte{1, 2, 3}, BidIndex: 7})
+	require.Nil(t, err)
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+
+		response := r.Get("/consensus/bidders?height=2&limit=2&offset=1")
+		require.Equal(t, 200, response.StatusCode)
+
+		var bidders capi.BiddersJSON
+		require.Nil(t, json.Unmarshal(response.RawBody, &bidders))
+		require.Len(t, bidders.Bids, 2)
+		require.Equal(t, uint64(101), bidders.Bids[0].Amount)
+		require.Equal(t, uint64(7), bidders.OwnBid.BidIndex)
+
+		response = r.Get("/consensus/bidders?height=3")
+		require.Equal(t, 404, response.StatusCode)
+
+		response = r.Get("/consensus/bidders?height=2&limit=-1")
+		require.Equal(t, 400, response.StatusCode)
+	})
+}
+
 func TestConsensusAPIRoundInfo(t *testing.T) {
 
 	//setup viper timeout
@@ -223,6 +268,41 @@ func TestConsensusAPIEventStatus(t *testing.T) {
 	})
 }
 
+func TestConsensusAPIQueueMetrics(t *testing.T) {
+
+	//setup viper timeout
+	cwd, err := os.Getwd()
+	require.Nil(t, err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../dusk.toml")
+	require.Nil(t, err)
+	cfg.Mock(&r)
+
+	apiServer, err := NewHTTPServer(nil, nil)
+	require.Nil(t, err)
+
+	for _, name := range []string{"events", "rounds"} {
+		err = apiServer.store.StoreQueueMetrics(capi.QueueMetricsJSON{Name: name, Stored: 1})
+		require.Nil(t, err)
+
+		// the latest metrics replace the previous ones
+		err = apiServer.store.StoreQueueMetrics(capi.QueueMetricsJSON{Name: name, Stored: 2})
+		require.Nil(t, err)
+	}
+
+	testflight.WithServer(apiServer.Server.Handler, func(r *testflight.Requester) {
+		response := r.Get("/consensus/queuemetrics")
+		require.NotNil(t, response)
+
+		var metrics []capi.QueueMetricsJSON
+		require.Nil(t, json.Unmarshal(response.RawBody, &metrics))
+		require.Len(t, metrics, 2)
+		for _, m := range metrics {
+			require.Equal(t, uint64(2), m.Stored)
+		}
+	})
+}
+
 func TestP2PLogsReader(t *testing.T) {
 
 	//setup viper timeout
@@ -110,8 +110,17 @@ func (s *Server) InitRouting() *pat.Router {
 	r.HandleFunc("/consensus/provisioners", capi.GetProvisionersHandler).Methods("GET")
 	r.HandleFunc("/consensus/roundinfo", capi.GetRoundInfoHandler).Methods("GET")
 	r.HandleFunc("/consensus/eventqueuestatus", capi.GetEventQueueStatusHandler).Methods("GET")
+	r.HandleFunc("/consensus/queuemetrics", capi.GetQueueMetricsHandler).Methods("GET")
+	r.HandleFunc("/consensus/participation", capi.GetParticipationHandler).Methods("GET")
+	r.HandleFunc("/consensus/checkpoint", capi.GetCheckpointHandler).Methods("GET")
+	r.HandleFunc("/mempool/verification", capi.GetMempoolVerificationHandler).Methods("GET")
 	r.HandleFunc("/p2p/logs", capi.GetP2PLogsHandler).Methods("GET")
 	r.HandleFunc("/p2p/count", capi.GetP2PCountHandler).Methods("GET")
+	r.HandleFunc("/p2p/peers", capi.GetP2PPeersHandler).Methods("GET")
+	r.HandleFunc("/p2p/bans", capi.GetP2PBansHandler).Methods("GET")
+	r.HandleFunc("/p2p/bans", capi.ClearP2PBansHandler).Methods("DELETE")
+	r.HandleFunc("/p2p/compression", capi.GetP2PCompressionHandler).Methods("GET")
+	r.HandleFunc("/p2p/bandwidth", capi.GetP2PBandwidthHandler).Methods("GET")
 
 	return r
 }
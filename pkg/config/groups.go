@@ -40,9 +40,45 @@ type logMonitorConfiguration struct {
 }
 
 type networkConfiguration struct {
-	Seeder  seedersConfiguration
-	Monitor monitorConfiguration
-	Port    string
+	Seeder    seedersConfiguration
+	Monitor   monitorConfiguration
+	Peers     peersConfiguration
+	Transport transportConfiguration
+	Limits    limitsConfiguration
+	Port      string
+}
+
+type transportConfiguration struct {
+	// Encryption upgrades the connections to the peers supporting it to
+	// TLS 1.3, authenticated by the node identity key
+	Encryption bool
+	// Required refuses the peers which do not support the encryption
+	Required bool
+	// IdentityKey is the file persisting the node identity key
+	IdentityKey string
+	// Compression compresses the messages sent to the peers supporting it
+	Compression bool
+	// CompressionThreshold is the size in bytes above which the messages
+	// are compressed
+	CompressionThreshold int
+}
+
+type limitsConfiguration struct {
+	// PeerBytes is the amount of bytes per second read from each peer. The
+	// reads beyond it are delayed
+	PeerBytes int
+	// Topics is the amount of messages per second of each topic accepted
+	// from each peer, by topic name. The messages beyond it are dropped
+	Topics map[string]float64
+}
+
+type lightConfiguration struct {
+	// Enabled runs the node in light mode: it syncs and verifies the block
+	// headers and their certificates only
+	Enabled bool
+	// Checkpoint is the file of the trusted checkpoint the light node syncs
+	// from
+	Checkpoint string
 }
 
 type kadcastConfiguration struct {
@@ -66,6 +102,26 @@ type monitorConfiguration struct {
 	Enabled bool
 }
 
+// peer manager configs
+type peersConfiguration struct {
+	// MaxInbound is the maximum amount of accepted connections
+	MaxInbound int
+	// TargetOutbound is the amount of connections the node dials and keeps
+	TargetOutbound int
+	// AddressBook is the file persisting the known good peer addresses
+	AddressBook string
+	// MinBackoff and MaxBackoff bound the delay (in seconds) before
+	// redialing a peer, which doubles on each failure
+	MinBackoff int64
+	MaxBackoff int64
+	// BanThreshold is the misbehaviour score banning a peer
+	BanThreshold uint32
+	// BanDuration is how long (in seconds) a peer stays banned
+	BanDuration int64
+	// BanList is the file persisting the banned peers
+	BanList string
+}
+
 type seedersConfiguration struct {
 	Addresses []string
 	Fixed     []string
@@ -159,6 +215,26 @@ type mempoolConfiguration struct {
 	PoolType    string
 	PreallocTxs uint32
 	MaxInvItems uint32
+	// TxTTL is the amount of seconds a tx is kept in the mempool. Zero falls
+	// back to the mempool default
+	TxTTL int64
+	// ReplaceByFeeMargin is the percentage by which the fee of a tx must
+	// beat the fees of the pooled txs spending the same nullifiers, in order
+	// to replace them
+	ReplaceByFeeMargin uint64
+	// VerifyWorkers is the amount of txs verified concurrently
+	VerifyWorkers uint32
+	// VerifyQueueSize is the amount of txs each source (network or local)
+	// can queue for verification
+	VerifyQueueSize uint32
+	// Persist the mempool to DumpFile on shutdown and reload it on startup
+	Persist bool
+	// DumpFile is the path of the mempool dump. Empty places it next to the
+	// chain database
+	DumpFile string
+	// JournalInterval is the amount of seconds between periodic dumps. Zero
+	// dumps on shutdown only
+	JournalInterval int64
 }
 
 type consensusConfiguration struct {
@@ -166,6 +242,58 @@ type consensusConfiguration struct {
 	DefaultAmount   uint64
 	// ConsensusTimeOut is the time out for consensus step timers.
 	ConsensusTimeOut int64
+
+	Queue        consensusQueueConfiguration
+	Signer       consensusSignerConfiguration
+	StakeManager consensusStakeManagerConfiguration
+}
+
+// consensus.Queue limits. Zero values fall back to the package defaults
+type consensusQueueConfiguration struct {
+	// MaxRoundsAhead is the look-ahead window relative to the current round
+	MaxRoundsAhead uint64
+	MaxPerRound    int
+	MaxPerStep     int
+	MaxPerSender   int
+	// MaxInMemory is the amount of messages kept in memory before spilling
+	// to SpillDir (or rejecting, if SpillDir is empty)
+	MaxInMemory int
+	// SpillDir is the parent directory of the spill storages. Each queue
+	// spills into its own subdirectory
+	SpillDir string
+}
+
+// consensus signer.Signer selection
+type consensusSignerConfiguration struct {
+	// Type is either "local" (default) or "remote"
+	Type string
+	// Network and Address of the remote signer process
+	Network string
+	Address string
+	// Token shared with the remote signer process. It is required on any
+	// network but unix
+	Token string
+	// Timeout of the calls to the remote signer, in milliseconds
+	Timeout int64
+}
+
+// stakemanager.Policy. Zero values fall back to the package defaults, or to
+// DefaultAmount and DefaultLockTime
+type consensusStakeManagerConfiguration struct {
+	Enabled bool
+	// RenewalOffset is how many blocks before their expiry stakes are renewed
+	RenewalOffset uint64
+	// Amount, in whole units of DUSK, staked when the node has no stake
+	Amount   uint64
+	LockTime uint64
+	// MaxStakeAmount, in whole units of DUSK, splits the renewed stakes
+	MaxStakeAmount uint64
+	// Merge the stakes expiring together into a single one
+	Merge bool
+	// Stop renewing and withdraw the stakes and the bid
+	Stop bool
+	// Store is the directory where the decisions are persisted
+	Store string
 }
 
 type genesisConfiguration struct {
@@ -54,6 +54,7 @@ type Registry struct {
 	Kadcast   kadcastConfiguration
 	Mempool   mempoolConfiguration
 	Consensus consensusConfiguration
+	Light     lightConfiguration
 
 	RPC rpcConfiguration
 	Gql gqlConfiguration
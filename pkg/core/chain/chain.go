@@ -12,6 +12,8 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
@@ -19,6 +21,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/loop"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
@@ -91,6 +94,14 @@ type Chain struct {
 	// Consensus loop
 	loop      *loop.Consensus
 	requestor *candidate.Requestor
+	// status records what the consensus loop is doing
+	status *consensus.StatusTracker
+
+	// verified remembers the candidates which passed verification
+	verified *verificationCache
+
+	// participation records the provisioners votes from the certificates
+	participation *participation.Tracker
 
 	// rusk client
 	proxy transactions.Proxy
@@ -112,6 +123,18 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		proxy:     proxy,
 		ctx:       ctx,
 		requestor: requestor,
+		status:    consensus.NewStatusTracker(),
+		verified:  newVerificationCache(),
+
+		participation: participation.NewTracker(),
+	}
+
+	if err := chain.status.Listen(rpcBus); err != nil {
+		log.WithError(err).Warn("could not register the consensus status on the RPCBus")
+	}
+
+	if err := chain.participation.Listen(rpcBus); err != nil {
+		log.WithError(err).Warn("could not register the provisioners participation on the RPCBus")
 	}
 
 	provisioners, err := proxy.Executor().GetProvisioners(ctx)
@@ -143,8 +166,15 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 		}
 	}
 
+	// the checkpoint is only served once the tip and the provisioners are
+	// known
+	if err := chain.listenCheckpoint(rpcBus); err != nil {
+		log.WithError(err).Warn("could not register the checkpoint on the RPCBus")
+	}
+
 	if srv != nil {
 		node.RegisterChainServer(srv, chain)
+		consensus.RegisterStatusServer(srv, chain.status)
 	}
 
 	return chain, nil
@@ -153,6 +183,18 @@ func New(ctx context.Context, db database.DB, eventBus *eventbus.EventBus, rpcBu
 // SetupConsensus adds the missing fields on the Chain which need to be populated
 // by the user. Once the fields are populated, consensus is started.
 func (c *Chain) SetupConsensus(pk keys.PublicKey, blsKeys key.Keys) error {
+	s, err := signer.FromConfig(blsKeys)
+	if err != nil {
+		return err
+	}
+
+	// the consensus only needs the public keys, the secret key stays with
+	// the signer
+	blsKeys, err = signer.PublicKeys(s)
+	if err != nil {
+		return err
+	}
+
 	c.lock.Lock()
 	c.pubKey = &pk
 	e := &consensus.Emitter{
@@ -161,6 +203,10 @@ func (c *Chain) SetupConsensus(pk keys.PublicKey, blsKeys key.Keys) error {
 		Keys:        blsKeys,
 		Proxy:       c.proxy,
 		TimerLength: config.ConsensusTimeOut,
+		Status:      c.status,
+		Signer:      s,
+
+		CandidatePreparation: c.prepareCandidate,
 	}
 
 	c.loop = loop.New(e)
@@ -251,10 +297,17 @@ func (c *Chain) onAcceptBlock(blk block.Block) error {
 	// Retrieve all successive blocks that need to be accepted
 	blks := c.sequencer.provideSuccessors(blk)
 
-	for _, blk := range blks {
+	for i, blk := range blks {
 		if err := c.AcceptBlock(c.ctx, blk); err != nil {
 			lg.WithError(err).Debug("could not AcceptBlock")
 			c.lock.Unlock()
+
+			// Only the first block was sent by the peer, the successors
+			// may come from any other one
+			var me *peer.MisbehaviourError
+			if i > 0 && errors.As(err, &me) {
+				return me.Err
+			}
 			return err
 		}
 		c.lastCertificate = blk.Header.Certificate
@@ -286,10 +339,13 @@ func (c *Chain) AcceptBlock(ctx context.Context, blk block.Block) error {
 
 	l.Trace("verifying block")
 
-	// 1. Check that stateless and stateful checks pass
-	if err := c.verifier.SanityCheckBlock(*c.tip, blk); err != nil {
+	// 1. Check that stateless and stateful checks pass. They are skipped
+	// for a candidate which was already verified on top of the current tip
+	if c.isVerified(blk) {
+		l.Trace("block already verified as candidate")
+	} else if err := c.verifier.SanityCheckBlock(*c.tip, blk); err != nil {
 		l.WithError(err).Error("block verification failed")
-		return err
+		return &peer.MisbehaviourError{Misbehaviour: peer.InvalidBlock, Err: err}
 	}
 
 	// 2. Check the certificate
@@ -299,9 +355,13 @@ func (c *Chain) AcceptBlock(ctx context.Context, blk block.Block) error {
 	l.Trace("verifying block certificate")
 	if err := verifiers.CheckBlockCertificate(*c.p, blk); err != nil {
 		l.WithError(err).Error("certificate verification failed")
-		return err
+		return &peer.MisbehaviourError{Misbehaviour: peer.InvalidBlock, Err: err}
 	}
 
+	// The certificate was produced by the provisioners preceding the state
+	// transition
+	certifiers := *c.p
+
 	// 3. Call ExecuteStateTransitionFunction
 	prov_num := c.p.Set.Len()
 	l.WithField("provisioners", prov_num).Info("calling ExecuteStateTransitionFunction")
@@ -322,8 +382,15 @@ func (c *Chain) AcceptBlock(ctx context.Context, blk block.Block) error {
 
 	if config.Get().API.Enabled {
 		go c.storeStakesInStormDB(blk.Header.Height)
+		go c.storeBiddersInStormDB(blk)
 	}
 
+	go func() {
+		if err := c.participation.Record(certifiers, blk); err != nil {
+			l.WithError(err).Warn("could not record the provisioners participation")
+		}
+	}()
+
 	// 4. Store the approved block
 	l.Trace("storing block in db")
 	if err := c.loader.Append(&blk); err != nil {
@@ -400,13 +467,7 @@ func (c *Chain) startConsensus() error {
 // VerifyCandidateBlock can be used as a callback for the consensus in order to
 // verify potential winning candidates.
 func (c *Chain) VerifyCandidateBlock(blk block.Block) error {
-	// We first perform a quick check on the Block Header and
-	if err := c.verifier.SanityCheckBlock(*c.tip, blk); err != nil {
-		return err
-	}
-
-	_, err := c.proxy.Executor().VerifyStateTransition(c.ctx, blk.Txs, blk.Header.Height)
-	return err
+	return c.verifyCandidate(*c.tip, blk)
 }
 
 // Send Inventory message to all peers
@@ -571,3 +632,45 @@ func (c *Chain) storeStakesInStormDB(blkHeight uint64) {
 		log.Warn("Could not store provisioners on memoryDB")
 	}
 }
+
+func (c *Chain) storeBiddersInStormDB(blk block.Block) {
+	store := capi.GetStormDBInstance()
+	bids := make([]capi.Bid, 0)
+	for _, tx := range blk.Txs {
+		if tx.Type() != transactions.Bid {
+			continue
+		}
+
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			log.WithError(err).Warn("Could not hash bid transaction")
+			continue
+		}
+
+		amount, fee := tx.Values()
+		bids = append(bids, capi.Bid{
+			TxID:   txid,
+			Amount: amount,
+			Fee:    fee,
+		})
+	}
+
+	// our own bid is only available if this node is a block generator
+	var ownBid *capi.OwnBid
+	_ = c.db.View(func(t database.Transaction) error {
+		d, _, bidIndex, err := t.FetchBidValues()
+		if err != nil {
+			return err
+		}
+
+		ownBid = &capi.OwnBid{
+			D:        d,
+			BidIndex: bidIndex,
+		}
+		return nil
+	})
+
+	if err := store.StoreBidders(blk.Header.Height, bids, ownBid); err != nil {
+		log.Warn("Could not store bidders on memoryDB")
+	}
+}
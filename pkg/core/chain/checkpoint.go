@@ -0,0 +1,133 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// Checkpoint is a trusted point of the chain, which the light nodes sync
+// from. It is made of a block header, and of the provisioners certifying the
+// blocks which follow it
+type Checkpoint struct {
+	Header       *block.Header
+	Provisioners user.Provisioners
+}
+
+// checkpointJSON is the persisted form of a Checkpoint. The provisioners are
+// kept in their wire encoding, hex-encoded
+type checkpointJSON struct {
+	Header       *block.Header `json:"header"`
+	Provisioners string        `json:"provisioners"`
+}
+
+// MarshalJSON implements json.Marshaler
+func (c Checkpoint) MarshalJSON() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := user.MarshalProvisioners(buf, &c.Provisioners); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(checkpointJSON{
+		Header:       c.Header,
+		Provisioners: hex.EncodeToString(buf.Bytes()),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (c *Checkpoint) UnmarshalJSON(data []byte) error {
+	var cj checkpointJSON
+	if err := json.Unmarshal(data, &cj); err != nil {
+		return err
+	}
+
+	if cj.Header == nil {
+		return errors.New("checkpoint without header")
+	}
+
+	b, err := hex.DecodeString(cj.Provisioners)
+	if err != nil {
+		return err
+	}
+
+	p, err := user.UnmarshalProvisioners(bytes.NewBuffer(b))
+	if err != nil {
+		return err
+	}
+
+	c.Header = cj.Header
+	c.Provisioners = p
+	return nil
+}
+
+// CheckpointFile returns the path of the light node checkpoint, as configured
+// or next to the chain database
+func CheckpointFile() string {
+	if path := config.Get().Light.Checkpoint; path != "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "checkpoint.json")
+}
+
+// LoadCheckpoint loads the checkpoint persisted at path, as exported by a
+// trusted node
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Checkpoint{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+
+	hash, err := c.Header.CalculateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(hash, c.Header.Hash) {
+		return nil, errors.New("checkpoint hash does not match its header")
+	}
+
+	return c, nil
+}
+
+// Checkpoint returns the tip of the chain, along with the provisioners
+// certifying the blocks which follow it
+func (c *Chain) Checkpoint() Checkpoint {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return Checkpoint{
+		Header:       c.tip.Header.Copy(),
+		Provisioners: c.p.Copy(),
+	}
+}
+
+// listenCheckpoint serves the topics.GetCheckpoint requests on the RPCBus, so
+// that the checkpoint of the chain can be exported to the light nodes
+func (c *Chain) listenCheckpoint(rpcBus *rpcbus.RPCBus) error {
+	reqChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetCheckpoint, reqChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(c.Checkpoint(), nil)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,442 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/verifiers"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	logger "github.com/sirupsen/logrus"
+)
+
+var lightLog = logger.WithFields(logger.Fields{"process": "light chain"})
+
+// ErrUnknownBlock is returned when requesting a block of which the header was
+// not synced
+var ErrUnknownBlock = errors.New("block header not synced")
+
+// LightChain follows the chain through the block headers and their
+// certificates only, starting from a trusted Checkpoint. Blocks and
+// transactions are requested from the peers on demand.
+// The certificates are verified against the provisioners of the checkpoint:
+// the stakes are only known through the state transitions, which the light
+// nodes do not execute. Once a header can not be certified, the provisioners
+// are tracked by reloading the checkpoint file, which the operator replaces
+// with a newer checkpoint exported by a trusted node.
+// Only the headers are trusted: the blocks and transactions fetched on demand
+// are whatever the first peer answering sends, as nothing certified commits
+// to the transactions of a block
+type LightChain struct {
+	eventBus *eventbus.EventBus
+	db       database.DB
+	loader   Loader
+
+	lock sync.Mutex
+	// tip is the last verified header
+	tip *block.Header
+	// provisioners of the checkpoint
+	p user.Provisioners
+	// cpHeight is the height of the checkpoint, and cpFile the file it is
+	// reloaded from
+	cpHeight uint64
+	cpFile   string
+
+	// requests are the channels waiting for the blocks and transactions
+	// requested through GetData, by hash
+	reqLock  sync.Mutex
+	requests map[string][]chan message.Message
+}
+
+// NewLightChain returns a LightChain resuming from the last header stored by
+// the loader, or from the checkpoint if it is higher
+func NewLightChain(db database.DB, eventBus *eventbus.EventBus, loader Loader, cp *Checkpoint) (*LightChain, error) {
+	tip, err := loader.LoadTip()
+	if err != nil {
+		return nil, err
+	}
+
+	if tip.Header.Height < cp.Header.Height {
+		tip = &block.Block{Header: cp.Header}
+		if err := loader.Append(tip); err != nil {
+			return nil, err
+		}
+	} else {
+		// the headers synced on a previous run must follow the checkpoint
+		blk, err := loader.BlockAt(cp.Header.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(blk.Header.Hash, cp.Header.Hash) {
+			return nil, errors.New("the synced headers do not follow the checkpoint")
+		}
+	}
+
+	lightLog.WithField("height", tip.Header.Height).Info("light chain loaded")
+	return &LightChain{
+		eventBus: eventBus,
+		db:       db,
+		loader:   loader,
+		tip:      tip.Header,
+		p:        cp.Provisioners,
+		cpHeight: cp.Header.Height,
+		cpFile:   CheckpointFile(),
+		requests: make(map[string][]chan message.Message),
+	}, nil
+}
+
+// Tip returns the last verified header
+func (l *LightChain) Tip() *block.Header {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.tip.Copy()
+}
+
+// MarshalGetHeaders returns a GetHeaders message, requesting the headers
+// following the tip
+func (l *LightChain) MarshalGetHeaders() (bytes.Buffer, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.marshalGetHeaders()
+}
+
+func (l *LightChain) marshalGetHeaders() (bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := createGetBlocksMsg(l.tip.Hash).Encode(buf); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	if err := topics.Prepend(buf, topics.GetHeaders); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return *buf, nil
+}
+
+// ProcessHeaders verifies and stores the headers following the tip. The
+// headers which are already known are skipped, since several peers may answer
+// with the same headers. More headers are requested from the peer if it sent
+// as many as it could.
+// The batch stops at the first header which the provisioners do not certify.
+// A forged certificate can not be told apart from provisioners which changed
+// since the checkpoint, so that the peer is not blamed, and the headers are
+// requested again only if a newer checkpoint could be loaded.
+// Satisfies the peer.ProcessorFunc interface.
+func (l *LightChain) ProcessHeaders(m message.Message) ([]bytes.Buffer, error) {
+	headers := m.Payload().(message.Headers).Headers
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var accepted int
+	var uncertified bool
+	for _, h := range headers {
+		if h.Height <= l.tip.Height {
+			continue
+		}
+
+		certified, err := l.acceptHeader(h)
+		if err != nil {
+			return nil, err
+		}
+
+		if !certified {
+			uncertified = true
+			break
+		}
+
+		accepted++
+	}
+
+	if uncertified {
+		if !l.reloadCheckpoint() {
+			return nil, nil
+		}
+	} else if accepted == 0 || len(headers) < message.MaxHeaders {
+		return nil, nil
+	}
+
+	buf, err := l.marshalGetHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	return []bytes.Buffer{buf}, nil
+}
+
+// acceptHeader verifies and stores a header following the tip. It tells if
+// the header was certified by the provisioners, and accepted
+func (l *LightChain) acceptHeader(h *block.Header) (bool, error) {
+	hash, err := h.CalculateHash()
+	if err != nil {
+		return false, err
+	}
+
+	if !bytes.Equal(hash, h.Hash) {
+		return false, errors.New("block hash does not match the header")
+	}
+
+	if err := verifiers.CheckHeader(l.tip, h); err != nil {
+		return false, err
+	}
+
+	if err := verifiers.CheckBlockCertificate(l.p, block.Block{Header: h}); err != nil {
+		lightLog.WithError(err).WithField("height", h.Height).
+			Warn("certificate verification failed, the provisioners may have changed since the checkpoint")
+		return false, nil
+	}
+
+	if err := l.loader.Append(&block.Block{Header: h}); err != nil {
+		return false, err
+	}
+
+	l.tip = h
+	lightLog.WithField("height", h.Height).Trace("header accepted")
+	return true, nil
+}
+
+// reloadCheckpoint adopts the checkpoint file if it was replaced by a newer
+// checkpoint. It tells if the provisioners were updated
+func (l *LightChain) reloadCheckpoint() bool {
+	cp, err := LoadCheckpoint(l.cpFile)
+	if err != nil {
+		lightLog.WithError(err).Warn("could not reload the checkpoint")
+		return false
+	}
+
+	if cp.Header.Height <= l.cpHeight {
+		lightLog.WithField("height", l.cpHeight).
+			Warn("a newer checkpoint is needed to follow the provisioners")
+		return false
+	}
+
+	if err := l.adoptCheckpoint(cp); err != nil {
+		lightLog.WithError(err).WithField("height", cp.Header.Height).
+			Error("could not adopt the checkpoint")
+		return false
+	}
+
+	lightLog.WithField("height", cp.Header.Height).Info("provisioners updated from a newer checkpoint")
+	return true
+}
+
+// adoptCheckpoint switches to the provisioners of a checkpoint. A checkpoint
+// ahead of the tip becomes the new tip, as on startup, while any other must
+// be part of the synced headers
+func (l *LightChain) adoptCheckpoint(cp *Checkpoint) error {
+	if cp.Header.Height > l.tip.Height {
+		if err := l.loader.Append(&block.Block{Header: cp.Header}); err != nil {
+			return err
+		}
+
+		l.tip = cp.Header
+	} else {
+		blk, err := l.loader.BlockAt(cp.Header.Height)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(blk.Header.Hash, cp.Header.Hash) {
+			return errors.New("the synced headers do not follow the checkpoint")
+		}
+	}
+
+	l.p = cp.Provisioners
+	l.cpHeight = cp.Header.Height
+	return nil
+}
+
+// ProcessInv requests the headers following the tip when a peer advertises
+// an unknown block. Other items are ignored.
+// Satisfies the peer.ProcessorFunc interface.
+func (l *LightChain) ProcessInv(m message.Message) ([]bytes.Buffer, error) {
+	inv := m.Payload().(message.Inv)
+	for _, item := range inv.InvList {
+		if item.Type != message.InvTypeBlock {
+			continue
+		}
+
+		if _, err := l.header(item.Hash); err == nil {
+			continue
+		}
+
+		buf, err := l.MarshalGetHeaders()
+		if err != nil {
+			return nil, err
+		}
+
+		return []bytes.Buffer{buf}, nil
+	}
+
+	return nil, nil
+}
+
+func (l *LightChain) header(hash []byte) (*block.Header, error) {
+	var h *block.Header
+	err := l.db.View(func(t database.Transaction) error {
+		var err error
+		h, err = t.FetchBlockHeader(hash)
+		return err
+	})
+
+	return h, err
+}
+
+// FetchBlock requests the block of a synced header from the peers, and waits
+// for the first one to answer. The block must match its header, yet the block
+// hash does not cover the transaction root, which is only checked against the
+// transactions themselves.
+// The transactions of the returned block are therefore untrusted: the peer
+// can replace them along with the transaction root. Callers must not take
+// them as the confirmed transactions of the block
+func (l *LightChain) FetchBlock(ctx context.Context, hash []byte) (block.Block, error) {
+	if _, err := l.header(hash); err != nil {
+		return block.Block{}, ErrUnknownBlock
+	}
+
+	m, err := l.request(ctx, message.InvTypeBlock, hash)
+	if err != nil {
+		return block.Block{}, err
+	}
+
+	return m.Payload().(block.Block), nil
+}
+
+// FetchTx requests an unconfirmed transaction from the mempool of the peers,
+// and waits for the first one to answer. Confirmed transactions are fetched
+// along with their block. As with FetchBlock, the transaction is untrusted
+func (l *LightChain) FetchTx(ctx context.Context, txID []byte) (transactions.ContractCall, error) {
+	m, err := l.request(ctx, message.InvTypeMempoolTx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Payload().(transactions.ContractCall), nil
+}
+
+// request sends a GetData message for an item to all peers, and waits for the
+// item to be delivered
+func (l *LightChain) request(ctx context.Context, t message.InvType, hash []byte) (message.Message, error) {
+	c := make(chan message.Message, 1)
+	k := string(hash)
+
+	l.reqLock.Lock()
+	l.requests[k] = append(l.requests[k], c)
+	l.reqLock.Unlock()
+	defer l.cancelRequest(k, c)
+
+	inv := &message.Inv{}
+	inv.AddItem(t, hash)
+
+	buf := new(bytes.Buffer)
+	if err := inv.Encode(buf); err != nil {
+		return nil, err
+	}
+
+	if err := topics.Prepend(buf, topics.GetData); err != nil {
+		return nil, err
+	}
+
+	errList := l.eventBus.Publish(topics.Gossip, message.New(topics.GetData, *buf))
+	diagnostics.LogPublishErrors("chain/light.go, topics.Gossip, topics.GetData", errList)
+
+	select {
+	case m := <-c:
+		return m, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *LightChain) cancelRequest(k string, c chan message.Message) {
+	l.reqLock.Lock()
+	defer l.reqLock.Unlock()
+	waiting := l.requests[k]
+	for i, w := range waiting {
+		if w == c {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiting) == 0 {
+		delete(l.requests, k)
+		return
+	}
+
+	l.requests[k] = waiting
+}
+
+func (l *LightChain) requested(hash []byte) bool {
+	l.reqLock.Lock()
+	defer l.reqLock.Unlock()
+	_, ok := l.requests[string(hash)]
+	return ok
+}
+
+// deliver hands a requested item over to the requests waiting for it
+func (l *LightChain) deliver(hash []byte, m message.Message) {
+	l.reqLock.Lock()
+	defer l.reqLock.Unlock()
+	for _, c := range l.requests[string(hash)] {
+		select {
+		case c <- m:
+		default:
+		}
+	}
+
+	delete(l.requests, string(hash))
+}
+
+// ProcessBlock delivers the requested blocks, once checked against their
+// header. Unsolicited blocks are ignored. The checks do not make the
+// transactions of the block trusted (see FetchBlock).
+// Satisfies the peer.ProcessorFunc interface.
+func (l *LightChain) ProcessBlock(m message.Message) ([]bytes.Buffer, error) {
+	blk := m.Payload().(block.Block)
+	if !l.requested(blk.Header.Hash) {
+		return nil, nil
+	}
+
+	h, err := l.header(blk.Header.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !h.Equals(blk.Header) {
+		return nil, errors.New("block does not match its header")
+	}
+
+	if err := checkIntegrity(blk); err != nil {
+		return nil, err
+	}
+
+	l.deliver(blk.Header.Hash, m)
+	return nil, nil
+}
+
+// ProcessTx delivers the requested transactions. Unsolicited transactions are
+// ignored, since the light nodes do not relay them.
+// Satisfies the peer.ProcessorFunc interface.
+func (l *LightChain) ProcessTx(m message.Message) ([]bytes.Buffer, error) {
+	tx := m.Payload().(transactions.ContractCall)
+	txID, err := tx.CalculateHash()
+	if err != nil {
+		return nil, err
+	}
+
+	if l.requested(txID) {
+		l.deliver(txID, m)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,240 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLightChainSync ensures the light chain only accepts the certified
+// headers following its tip
+func TestLightChainSync(t *testing.T) {
+	assert := require.New(t)
+	p, k := consensus.MockProvisioners(10)
+	lc, cp := setupLightChain(t, *p)
+	assert.Equal(cp.Header.Hash, lc.Tip().Hash)
+
+	headers := certifiedHeaders(cp.Header, 3, p, k)
+	resp, err := lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: headers}))
+	assert.NoError(err)
+	assert.Empty(resp)
+	assert.Equal(headers[2].Hash, lc.Tip().Hash)
+
+	// the headers sent by another peer are skipped
+	_, err = lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: headers}))
+	assert.NoError(err)
+	assert.Equal(headers[2].Hash, lc.Tip().Hash)
+
+	// headers which do not follow the tip are refused
+	orphan := certifiedHeaders(lightHeader(helper.RandomHeader(cp.Header.Height+3)), 1, p, k)
+	_, err = lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: orphan}))
+	assert.Error(err)
+
+	// so are the headers certified by other provisioners, without blaming the
+	// peer
+	other, otherKeys := consensus.MockProvisioners(10)
+	forged := certifiedHeaders(headers[2], 1, other, otherKeys)
+	_, err = lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: forged}))
+	assert.NoError(err)
+	assert.Equal(headers[2].Hash, lc.Tip().Hash)
+
+	// the announcement of an unknown block triggers a GetHeaders
+	inv := message.Inv{}
+	inv.AddItem(message.InvTypeBlock, headers[1].Hash)
+	resp, err = lc.ProcessInv(message.New(topics.Inv, inv))
+	assert.NoError(err)
+	assert.Empty(resp)
+
+	inv.AddItem(message.InvTypeBlock, forged[0].Hash)
+	resp, err = lc.ProcessInv(message.New(topics.Inv, inv))
+	assert.NoError(err)
+	assert.Len(resp, 1)
+
+	topic, err := topics.Extract(&resp[0])
+	assert.NoError(err)
+	assert.Equal(topics.GetHeaders, topic)
+
+	getHeaders := &message.GetBlocks{}
+	assert.NoError(getHeaders.Decode(&resp[0]))
+	assert.Equal(headers[2].Hash, getHeaders.Locators[0])
+}
+
+// TestLightChainNewCheckpoint ensures the batch of headers stops at the first
+// uncertified header, and that the provisioners are updated from a newer
+// checkpoint
+func TestLightChainNewCheckpoint(t *testing.T) {
+	assert := require.New(t)
+	p, k := consensus.MockProvisioners(10)
+	lc, cp := setupLightChain(t, *p)
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	lc.cpFile = filepath.Join(dir, "checkpoint.json")
+
+	// the provisioners change after the first header
+	other, otherKeys := consensus.MockProvisioners(10)
+	headers := certifiedHeaders(cp.Header, 1, p, k)
+	headers = append(headers, certifiedHeaders(headers[0], 2, other, otherKeys)...)
+
+	resp, err := lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: headers}))
+	assert.NoError(err)
+	assert.Empty(resp)
+	assert.Equal(headers[0].Hash, lc.Tip().Hash)
+
+	// the checkpoint exported once the provisioners changed
+	data, err := json.Marshal(Checkpoint{Header: headers[0], Provisioners: *other})
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(lc.cpFile, data, 0600))
+
+	// the headers are requested again once the checkpoint is reloaded
+	resp, err = lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: headers}))
+	assert.NoError(err)
+	assert.Len(resp, 1)
+	assert.Equal(headers[0].Hash, lc.Tip().Hash)
+
+	_, err = lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: headers}))
+	assert.NoError(err)
+	assert.Equal(headers[2].Hash, lc.Tip().Hash)
+}
+
+// TestLightChainFetchBlock ensures the blocks are requested from the peers,
+// and delivered once checked against their header
+func TestLightChainFetchBlock(t *testing.T) {
+	assert := require.New(t)
+	p, k := consensus.MockProvisioners(10)
+	lc, cp := setupLightChain(t, *p)
+
+	blk := helper.RandomBlock(cp.Header.Height+1, 1)
+	certify(blk.Header, cp.Header, p, k)
+	_, err := lc.ProcessHeaders(message.New(topics.Headers, message.Headers{Headers: []*block.Header{blk.Header.Copy()}}))
+	assert.NoError(err)
+
+	_, err = lc.FetchBlock(context.Background(), helper.RandomBlock(1, 1).Header.Hash)
+	assert.Equal(ErrUnknownBlock, err)
+
+	gossip := make(chan message.Message, 1)
+	lc.eventBus.Subscribe(topics.Gossip, eventbus.NewChanListener(gossip))
+
+	fetched := make(chan block.Block, 1)
+	go func() {
+		b, err := lc.FetchBlock(context.Background(), blk.Header.Hash)
+		if err == nil {
+			fetched <- b
+		}
+	}()
+
+	// the block is requested with a GetData
+	req := <-gossip
+	buf := req.Payload().(message.SafeBuffer).Buffer
+	topic, err := topics.Extract(&buf)
+	assert.NoError(err)
+	assert.Equal(topics.GetData, topic)
+
+	// a block which does not match its header is refused
+	tampered := helper.RandomBlock(cp.Header.Height+1, 1)
+	tampered.Header = blk.Header.Copy()
+	_, err = lc.ProcessBlock(message.New(topics.Block, *tampered))
+	assert.Error(err)
+
+	_, err = lc.ProcessBlock(message.New(topics.Block, *blk))
+	assert.NoError(err)
+
+	select {
+	case b := <-fetched:
+		assert.Equal(blk.Header.Hash, b.Header.Hash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not delivered")
+	}
+}
+
+// TestCheckpointFile ensures the checkpoints exported by the full nodes can be
+// loaded, as long as their header is intact
+func TestCheckpointFile(t *testing.T) {
+	assert := require.New(t)
+	p, _ := consensus.MockProvisioners(3)
+	cp := Checkpoint{Header: lightHeader(helper.RandomHeader(10)), Provisioners: *p}
+
+	dir, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	data, err := json.Marshal(cp)
+	assert.NoError(err)
+
+	path := filepath.Join(dir, "checkpoint.json")
+	assert.NoError(ioutil.WriteFile(path, data, 0600))
+
+	loaded, err := LoadCheckpoint(path)
+	assert.NoError(err)
+	assert.True(cp.Header.Equals(loaded.Header))
+
+	expected, err := p.Hash()
+	assert.NoError(err)
+	actual, err := loaded.Provisioners.Hash()
+	assert.NoError(err)
+	assert.Equal(expected, actual)
+
+	cp.Header.Height++
+	data, err = json.Marshal(cp)
+	assert.NoError(err)
+	assert.NoError(ioutil.WriteFile(path, data, 0600))
+
+	_, err = LoadCheckpoint(path)
+	assert.Error(err)
+}
+
+func setupLightChain(t *testing.T, p user.Provisioners) (*LightChain, *Checkpoint) {
+	_, db := lite.CreateDBConnection()
+	cp := &Checkpoint{Header: lightHeader(helper.RandomHeader(5)), Provisioners: p}
+
+	lc, err := NewLightChain(db, eventbus.New(), createLoader(db), cp)
+	require.NoError(t, err)
+	return lc, cp
+}
+
+// lightHeader sets the hash of a header
+func lightHeader(h *block.Header) *block.Header {
+	hash, err := h.CalculateHash()
+	if err != nil {
+		panic(err)
+	}
+
+	h.Hash = hash
+	return h
+}
+
+// certify makes a header follow prev, and certifies it by the provisioners
+func certify(h *block.Header, prev *block.Header, p *user.Provisioners, k []key.Keys) {
+	h.Height = prev.Height + 1
+	h.PrevBlockHash = prev.Hash
+	h.Timestamp = prev.Timestamp + 10
+	lightHeader(h)
+	h.Certificate = message.MockAgreement(h.Hash, h.Height, 3, k, p).GenerateCertificate()
+}
+
+func certifiedHeaders(prev *block.Header, amount int, p *user.Provisioners, k []key.Keys) []*block.Header {
+	headers := make([]*block.Header, amount)
+	for i := range headers {
+		headers[i] = helper.RandomHeader(0)
+		certify(headers[i], prev, p, k)
+		prev = headers[i]
+	}
+
+	return headers
+}
@@ -0,0 +1,144 @@
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+)
+
+// verificationCacheSize is the amount of verified candidates remembered. A
+// handful of candidates compete in each round, so this covers a few rounds
+const verificationCacheSize = 64
+
+// verificationCache remembers the candidate blocks which passed the sanity
+// checks and the Rusk state transition verification. Entries are keyed by the
+// candidate hash, its transaction root (which the block hash does not cover)
+// and the hash of the tip it was verified on top of: as long as the tip (and
+// therefore the state) did not change, the outcome of the verification does
+// not change either
+type verificationCache struct {
+	lock     sync.Mutex
+	verified map[string]struct{}
+	// order of insertion, for the eviction of the oldest entries
+	order []string
+}
+
+func newVerificationCache() *verificationCache {
+	return &verificationCache{
+		verified: make(map[string]struct{}),
+		order:    make([]string, 0, verificationCacheSize),
+	}
+}
+
+func verificationKey(blk block.Block, parent []byte) string {
+	return string(blk.Header.Hash) + string(blk.Header.TxRoot) + string(parent)
+}
+
+func (v *verificationCache) add(blk block.Block, parent []byte) {
+	k := verificationKey(blk, parent)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if _, ok := v.verified[k]; ok {
+		return
+	}
+
+	if len(v.order) == verificationCacheSize {
+		delete(v.verified, v.order[0])
+		v.order = v.order[1:]
+	}
+
+	v.verified[k] = struct{}{}
+	v.order = append(v.order, k)
+}
+
+func (v *verificationCache) has(blk block.Block, parent []byte) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	_, ok := v.verified[verificationKey(blk, parent)]
+	return ok
+}
+
+// verifyCandidate runs the sanity checks and the Rusk state transition
+// verification of a candidate on top of the given tip, unless it was already
+// verified
+func (c *Chain) verifyCandidate(tip block.Block, blk block.Block) error {
+	if c.verified.has(blk, tip.Header.Hash) {
+		return checkIntegrity(blk)
+	}
+
+	// We first perform a quick check on the Block Header and
+	if err := c.verifier.SanityCheckBlock(tip, blk); err != nil {
+		return err
+	}
+
+	if _, err := c.proxy.Executor().VerifyStateTransition(c.ctx, blk.Txs, blk.Header.Height); err != nil {
+		return err
+	}
+
+	c.verified.add(blk, tip.Header.Hash)
+	return nil
+}
+
+// isVerified tells if a block was verified on top of the current tip, and
+// still matches the verified candidate
+func (c *Chain) isVerified(blk block.Block) bool {
+	return c.verified.has(blk, c.tip.Header.Hash) && checkIntegrity(blk) == nil
+}
+
+// prepareCandidate verifies in the background the expected winner of the
+// round, so that its acceptance only needs the state transition execution
+// once the certificate arrives.
+// The execution itself cannot be anticipated, since ExecuteStateTransition
+// mutates the Rusk state irreversibly and the certificate might still be for
+// another block
+func (c *Chain) prepareCandidate(hash []byte) {
+	go func() {
+		c.lock.RLock()
+		tip := *c.tip
+		c.lock.RUnlock()
+
+		var cm block.Block
+		if err := c.db.View(func(t database.Transaction) error {
+			var err error
+			cm, err = t.FetchCandidateMessage(hash)
+			return err
+		}); err != nil {
+			log.WithError(err).Debug("expected winner not available for preparation")
+			return
+		}
+
+		// verifyCandidate is a no-op if the first reduction verified it
+		if err := c.verifyCandidate(tip, cm); err != nil {
+			log.WithError(err).Warn("expected winner failed verification")
+		}
+	}()
+}
+
+// checkIntegrity makes sure that the header hash and the transaction root of
+// a block match its content, so that a cached verification outcome can be
+// trusted for it
+func checkIntegrity(blk block.Block) error {
+	hash, err := blk.Header.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash, blk.Header.Hash) {
+		return errors.New("block hash does not match the header")
+	}
+
+	root, err := blk.CalculateRoot()
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(root, blk.Header.TxRoot) {
+		return errors.New("merkle root mismatch")
+	}
+
+	return nil
+}
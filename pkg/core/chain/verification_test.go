@@ -0,0 +1,86 @@
+package chain
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	assert "github.com/stretchr/testify/require"
+)
+
+type countingVerifier struct {
+	MockVerifier
+	checks int
+}
+
+func (v *countingVerifier) SanityCheckBlock(prevBlock block.Block, blk block.Block) error {
+	v.checks++
+	return nil
+}
+
+// TestVerificationCache tests that a candidate is verified only once on top
+// of the same tip, and that the cached outcome is not reused for a block
+// which does not match the verified candidate
+func TestVerificationCache(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 1)
+	v := &countingVerifier{}
+	c.verifier = v
+
+	blk := helper.RandomBlock(c.tip.Header.Height+1, 2)
+	assert.NoError(c.VerifyCandidateBlock(*blk))
+	assert.NoError(c.VerifyCandidateBlock(*blk))
+	assert.Equal(1, v.checks)
+	assert.True(c.isVerified(*blk))
+
+	// same hash, different transactions
+	other := helper.RandomBlock(c.tip.Header.Height+1, 2)
+	tampered := block.Block{Header: blk.Header.Copy(), Txs: other.Txs}
+	tampered.Header.TxRoot = other.Header.TxRoot
+	assert.False(c.isVerified(tampered))
+
+	// same hash and root, forged header
+	forged := block.Block{Header: blk.Header.Copy(), Txs: blk.Txs}
+	forged.Header.Timestamp++
+	assert.False(c.isVerified(forged))
+
+	// a new tip invalidates the verification
+	c.tip = blk
+	assert.False(c.isVerified(*blk))
+}
+
+type countingExecutor struct {
+	*transactions.PermissiveExecutor
+	executions int32
+}
+
+func (e *countingExecutor) ExecuteStateTransition(ctx context.Context, cc []transactions.ContractCall, height uint64) (user.Provisioners, error) {
+	atomic.AddInt32(&e.executions, 1)
+	return e.PermissiveExecutor.ExecuteStateTransition(ctx, cc, height)
+}
+
+// TestPrepareCandidate tests that the expected winner is verified ahead of
+// its certificate, but never executed, since the execution cannot be undone
+func TestPrepareCandidate(t *testing.T) {
+	assert := assert.New(t)
+	_, c := setupChainTest(t, 1)
+	e := &countingExecutor{PermissiveExecutor: transactions.MockExecutor(1)}
+	c.proxy = &transactions.MockProxy{E: e}
+
+	blk := helper.RandomBlock(c.tip.Header.Height+1, 2)
+	assert.NoError(c.db.Update(func(t database.Transaction) error {
+		return t.StoreCandidateMessage(*blk)
+	}))
+
+	c.prepareCandidate(blk.Header.Hash)
+	assert.Eventually(func() bool {
+		return c.verified.has(*blk, c.tip.Header.Hash)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(int32(0), atomic.LoadInt32(&e.executions))
+}
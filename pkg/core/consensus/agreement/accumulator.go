@@ -3,6 +3,7 @@ package agreement
 import (
 	"sync"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	log "github.com/sirupsen/logrus"
 )
@@ -15,12 +16,14 @@ type Accumulator struct {
 	eventChan          chan message.Agreement
 	CollectedVotesChan chan []message.Agreement
 	store              *store
+	status             *consensus.StatusTracker
 
 	workersQuitChan chan struct{}
 }
 
 // NewAccumulator initializes a worker pool, starts up an Accumulator and returns it.
-func newAccumulator(handler Handler, workerAmount int) *Accumulator {
+// The quorum progress is recorded on the StatusTracker, if not nil
+func newAccumulator(handler Handler, workerAmount int, status *consensus.StatusTracker) *Accumulator {
 	// create accumulator
 	a := &Accumulator{
 		handler:            handler,
@@ -28,6 +31,7 @@ func newAccumulator(handler Handler, workerAmount int) *Accumulator {
 		eventChan:          make(chan message.Agreement, 100),
 		CollectedVotesChan: make(chan []message.Agreement, 1),
 		store:              newStore(),
+		status:             status,
 		workersQuitChan:    make(chan struct{}),
 	}
 
@@ -64,6 +68,7 @@ func (a *Accumulator) Accumulate() {
 			continue
 		}
 
+		a.status.SetAgreementVotes(hdr.Step, count, a.handler.Quorum(hdr.Round))
 		lg.WithFields(log.Fields{
 			"step":   ev.State().Step,
 			"round":  ev.State().Round,
@@ -12,7 +12,7 @@ func TestAccumulatorProcessing(t *testing.T) {
 	hlp := NewHelper(nr)
 	hash, _ := crypto.RandEntropy(32)
 	handler := NewHandler(hlp.Keys, *hlp.P)
-	accumulator := newAccumulator(handler, 4)
+	accumulator := newAccumulator(handler, 4, nil)
 
 	evs := hlp.Spawn(hash)
 	for _, msg := range evs {
@@ -38,7 +38,7 @@ func (s *Loop) GetControlFn() consensus.ControlFn {
 func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementChan <-chan message.Message, r consensus.RoundUpdate) (*block.Certificate, []byte) {
 	// creating accumulator and handler
 	h := NewHandler(s.Keys, r.P)
-	acc := newAccumulator(h, WorkerAmount)
+	acc := newAccumulator(h, WorkerAmount, s.Status)
 
 	// deferring queue cleanup at the end of the execution of this round
 	defer func() {
@@ -65,6 +65,7 @@ func (s *Loop) Run(ctx context.Context, roundQueue *consensus.Queue, agreementCh
 				Debugln("quorum reached")
 
 			cert := evs[0].GenerateCertificate()
+			s.Status.SetLastCertificate(cert)
 			return cert, evs[0].State().BlockHash
 
 		case <-ctx.Done():
@@ -98,7 +99,16 @@ func (s *Loop) shouldCollectNow(a message.Message, round uint64, queue *consensu
 				"coordinator_round": round,
 			}).
 			Debugln("storing future round for later")
-		queue.PutEvent(hdr.Round, hdr.Step, a)
+		if err := queue.PutEvent(hdr.Round, hdr.Step, a); err != nil {
+			lg.
+				WithError(err).
+				WithFields(log.Fields{
+					"topic":             "Agreement",
+					"round":             hdr.Round,
+					"coordinator_round": round,
+				}).
+				Debugln("discarding future agreement")
+		}
 		return false
 	}
 
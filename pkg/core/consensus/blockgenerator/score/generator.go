@@ -11,7 +11,6 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
-	"github.com/dusk-network/dusk-crypto/bls"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -60,15 +59,6 @@ func New(e *consensus.Emitter, db database.DB) (Generator, error) {
 	}, nil
 }
 
-func (p *generator) sign(seed []byte) ([]byte, error) {
-	signedSeed, err := bls.Sign(p.Keys.BLSSecretKey, p.Keys.BLSPubKey, seed)
-	if err != nil {
-		return nil, err
-	}
-	compSeed := signedSeed.Compress()
-	return compSeed, nil
-}
-
 // Generate the score and if it is above the treshold pass it to the block
 // generator for propagating it to the network
 func (p *generator) Generate(ctx context.Context, r consensus.RoundUpdate, step uint8) message.ScoreProposal {
@@ -81,7 +71,7 @@ func (p *generator) Generate(ctx context.Context, r consensus.RoundUpdate, step
 		BlockHash: emptyHash[:],
 	}
 
-	seed, err := p.sign(r.Seed)
+	seed, err := p.SignSeed(r.Round, step, r.Seed)
 	if err != nil {
 		//TODO: this probably deserves a panic
 		lg.WithError(err).Errorln("problem in signing the seed during the generation")
@@ -1,12 +1,19 @@
 package capi
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/asdine/storm/v3"
 	"github.com/asdine/storm/v3/q"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
 	"github.com/sirupsen/logrus"
@@ -29,8 +36,43 @@ func StartAPI(eb *eventbus.EventBus, rb *rpcbus.RPCBus) {
 		Debug("StartAPI")
 }
 
-// GetBiddersHandler will return a json response
-//FIXME this is not yet implemented since we dont have the info yet
+const (
+	// defaultPageLimit is the amount of items returned when the request does
+	// not specify a limit
+	defaultPageLimit = 100
+	// maxPageLimit is the maximum amount of items returned in one page
+	maxPageLimit = 1000
+)
+
+// pagination reads the optional `limit` and `offset` query parameters
+func pagination(req *http.Request) (int, int, error) {
+	limit, offset := defaultPageLimit, 0
+
+	if limitStr := req.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 {
+			return 0, 0, errors.New("invalid limit")
+		}
+
+		limit = l
+		if limit > maxPageLimit {
+			limit = maxPageLimit
+		}
+	}
+
+	if offsetStr := req.URL.Query().Get("offset"); offsetStr != "" {
+		o, err := strconv.Atoi(offsetStr)
+		if err != nil || o < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+
+		offset = o
+	}
+
+	return limit, offset, nil
+}
+
+// GetBiddersHandler will return BiddersJSON json. The bid list is paginated
 func GetBiddersHandler(res http.ResponseWriter, req *http.Request) {
 	heightStr := req.URL.Query().Get("height")
 	if heightStr == "" {
@@ -43,9 +85,36 @@ func GetBiddersHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	limit, offset, err := pagination(req)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	log.WithField("height", height).Debug("GetBidders")
-	_, _ = res.Write([]byte(`{"error":"not yet implemented"}`))
+	var bidders BiddersJSON
+	err = GetStormDBInstance().Find("ID", uint64(height), &bidders)
+	if err != nil {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if offset >= len(bidders.Bids) {
+		bidders.Bids = []Bid{}
+	} else {
+		bidders.Bids = bidders.Bids[offset:]
+		if len(bidders.Bids) > limit {
+			bidders.Bids = bidders.Bids[:limit]
+		}
+	}
 
+	var b []byte
+	b, err = json.Marshal(bidders)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
 }
 
 // GetProvisionersHandler will return Provisioners json
@@ -105,6 +174,12 @@ func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	limit, offset, err := pagination(req)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	log.
 		WithField("heightBegin", heightBegin).
 		WithField("heightEnd", heightEnd).
@@ -113,7 +188,7 @@ func GetRoundInfoHandler(res http.ResponseWriter, req *http.Request) {
 	var roundInfos []RoundInfoJSON
 
 	//TODO: step should be a argument for query ?
-	err = GetStormDBInstance().DB.Range("Round", heightBegin, heightEnd, &roundInfos)
+	err = GetStormDBInstance().DB.Range("Round", uint64(heightBegin), uint64(heightEnd), &roundInfos, storm.Limit(limit), storm.Skip(offset))
 	if err != nil {
 		res.WriteHeader(http.StatusInternalServerError)
 		return
@@ -146,11 +221,17 @@ func GetEventQueueStatusHandler(res http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	limit, offset, err := pagination(req)
+	if err != nil {
+		res.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
 	log.WithField("height", height).Debug("GetEventQueueStatusHandler")
 
 	//TODO: stepBegin and stepEnd should be req parameters ?
 	var eventQueueList []EventQueueJSON
-	err = GetStormDBInstance().DB.Select(q.Gte("Round", uint64(height)), q.Lte("Round", uint64(height))).Find(&eventQueueList)
+	err = GetStormDBInstance().DB.Select(q.Gte("Round", uint64(height)), q.Lte("Round", uint64(height))).Limit(limit).Skip(offset).Find(&eventQueueList)
 	if err != nil {
 		log.WithError(err).Error("could not execute query GetEventQueueStatusHandler")
 		res.WriteHeader(http.StatusNotFound)
@@ -167,6 +248,237 @@ func GetEventQueueStatusHandler(res http.ResponseWriter, req *http.Request) {
 	_, _ = res.Write(b)
 }
 
+// GetQueueMetricsHandler will return the latest metrics of the consensus
+// queues in json
+func GetQueueMetricsHandler(res http.ResponseWriter, req *http.Request) {
+	var metrics []QueueMetricsJSON
+	if err := GetStormDBInstance().DB.All(&metrics); err != nil {
+		log.WithError(err).Error("could not execute query GetQueueMetricsHandler")
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	b, err := json.Marshal(metrics)
+	if err != nil {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_, _ = res.Write(b)
+}
+
+// GetParticipationHandler will return the participation stats of the
+// provisioners, or of the one identified by the optional hex-encoded `key`
+func GetParticipationHandler(res http.ResponseWriter, req *http.Request) {
+	var pubKeyBLS []byte
+	if keyStr := req.URL.Query().Get("key"); keyStr != "" {
+		var err error
+		pubKeyBLS, err = hex.DecodeString(keyStr)
+		if err != nil {
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetParticipation, rpcbus.NewRequest(pubKeyBLS), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetParticipationHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	stats, ok := resp.([]participation.Stats)
+	if !ok {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pubKeyBLS != nil && len(stats) == 0 {
+		res.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var b []byte
+	b, err = json.Marshal(stats)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetCheckpointHandler will return the checkpoint of the chain tip in json,
+// which the light nodes sync from
+func GetCheckpointHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetCheckpoint, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetCheckpointHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetMempoolVerificationHandler will return the metrics of the mempool
+// verification queues in json
+func GetMempoolVerificationHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetMempoolTXs) * time.Second
+	resp, err := rpcBus.Call(topics.GetVerificationStats, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetMempoolVerificationHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetP2PPeersHandler will return the connected peers in json
+func GetP2PPeersHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetPeers, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetP2PPeersHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetP2PBansHandler will return the banned peers in json
+func GetP2PBansHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetBans, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetP2PBansHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// ClearP2PBansHandler will lift the ban of the host query param, or all of
+// them if it is missing, and return the amount of lifted bans in json
+func ClearP2PBansHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	host := req.URL.Query().Get("host")
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.ClearBans, rpcbus.NewRequest(host), timeout)
+	if err != nil {
+		log.WithError(err).Debug("ClearP2PBansHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetP2PCompressionHandler will return the compression statistics in json
+func GetP2PCompressionHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetCompressionStats, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetP2PCompressionHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
+// GetP2PBandwidthHandler will return the bandwidth statistics in json, by
+// topic and by connected peer
+func GetP2PBandwidthHandler(res http.ResponseWriter, req *http.Request) {
+	if rpcBus == nil {
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	timeout := time.Duration(cfg.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := rpcBus.Call(topics.GetBandwidthStats, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		log.WithError(err).Debug("GetP2PBandwidthHandler")
+		res.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	b, err := json.Marshal(resp)
+	if err != nil {
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_, _ = res.Write(b)
+}
+
 // GetP2PLogsHandler will return PeerJSON json
 func GetP2PLogsHandler(res http.ResponseWriter, req *http.Request) {
 	typeStr := req.URL.Query().Get("type")
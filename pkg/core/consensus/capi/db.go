@@ -1,253 +1,75 @@
 package capi
 
-//
-//import (
-//	"bytes"
-//	"encoding/json"
-//	"fmt"
-//	"time"
-//
-//	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
-//	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
-//	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
-//	"github.com/tidwall/buntdb"
-//)
-//
-////nolint
-//var (
-//	ProvisionersPrefix = "provisioner"
-//	RoundInfoPrefix    = "roundinfo"
-//	EventQueuePrefix   = "eventqueue"
-//	buntStoreInstance  *BuntStore
-//)
-//
-//// Level is the database level
-//type Level int
-//
-////nolint
-//const (
-//	Low    Level = -1
-//	Medium Level = 0
-//	High   Level = 1
-//)
-//
-//// BuntStore provides access to BuntDB
-//type BuntStore struct {
-//	// db is the handle to db
-//	db *buntdb.DB
-//	// The path to the BuntDB file
-//	path string
-//}
-//
-//// GetBuntStoreInstance will return the actual db instance or nil
-//func GetBuntStoreInstance() *BuntStore {
-//	if buntStoreInstance == nil {
-//		panic("BuntStore instance is nil")
-//	}
-//	return buntStoreInstance
-//}
-//
-//// SetBuntStoreInstance will set a store
-//func SetBuntStoreInstance(store *BuntStore) {
-//	buntStoreInstance = store
-//}
-//
-//// NewBuntStore takes a file path and returns a connected Raft backend.
-//func NewBuntStore(path string, durability Level) (*BuntStore, error) {
-//	// Try to connect
-//	db, err := buntdb.Open(path)
-//	if err != nil {
-//		return nil, err
-//	}
-//
-//	// Disable the AutoShrink. Shrinking should only be manually
-//	// handled following a log compaction.
-//	var config buntdb.Config
-//	if err := db.ReadConfig(&config); err != nil {
-//		_ = db.Close()
-//		return nil, err
-//	}
-//	config.AutoShrinkDisabled = true
-//	switch durability {
-//	case Low:
-//		config.SyncPolicy = buntdb.Never
-//	case Medium:
-//		config.SyncPolicy = buntdb.EverySecond
-//	case High:
-//		config.SyncPolicy = buntdb.Always
-//	}
-//	if err := db.SetConfig(config); err != nil {
-//		_ = db.Close()
-//		return nil, err
-//	}
-//
-//	// Create the new store
-//	store := &BuntStore{
-//		db:   db,
-//		path: path,
-//	}
-//	return store, nil
-//}
-//
-//// Close is used to gracefully close the DB connection.
-//func (b *BuntStore) Close() error {
-//	return b.db.Close()
-//}
-//
-//// GetKey will get a composed key
-//func GetKey(name string, value interface{}) string {
-//	return fmt.Sprintf("%s:%v", name, value)
-//}
-//
-//// FetchProvisioners will get the Provisioners from db
-//func (b *BuntStore) FetchProvisioners(height uint64) (*user.Provisioners, error) {
-//	var provisioners user.Provisioners
-//	err := b.db.View(func(t *buntdb.Tx) error {
-//		key := GetKey(ProvisionersPrefix, height)
-//		provisionersStr, err := t.Get(key)
-//		if err != nil {
-//			return err
-//		}
-//
-//		buf := new(bytes.Buffer)
-//		_, err = buf.WriteString(provisionersStr)
-//		if err != nil {
-//			return err
-//		}
-//
-//		provisioners, err = user.UnmarshalProvisioners(buf)
-//		return err
-//	})
-//	return &provisioners, err
-//}
-//
-//// StoreProvisioners will store the Provisioners into db
-//func (b *BuntStore) StoreProvisioners(provisioners *user.Provisioners, height uint64) error {
-//	err := b.db.Update(func(tx *buntdb.Tx) error {
-//
-//		var init []byte
-//		buf := bytes.NewBuffer(init)
-//
-//		err := user.MarshalProvisioners(buf, provisioners)
-//		if err != nil {
-//			return err
-//		}
-//
-//		key := GetKey(ProvisionersPrefix, height)
-//		expirationTime := cfg.Get().API.ExpirationTime
-//		_, _, err = tx.Set(key, buf.String(), &buntdb.SetOptions{Expires: true, TTL: time.Duration(expirationTime) * time.Second})
-//		return err
-//	})
-//	return err
-//}
-//
-//// FetchRoundInfo will get the RoundInfoJSON info from db
-//func (b *BuntStore) FetchRoundInfo(round uint64, stepBegin, stepEnd uint8) ([]RoundInfoJSON, error) {
-//	var targetJSONList []RoundInfoJSON
-//	err := b.db.View(func(t *buntdb.Tx) error {
-//		var err error
-//		for i := int(stepBegin); i < int(stepEnd); i++ {
-//			var targetJSON RoundInfoJSON
-//			key := GetKey(RoundInfoPrefix, fmt.Sprintf("%d:%d", round, i))
-//			var eventQueueJSONStr string
-//			eventQueueJSONStr, err = t.Get(key)
-//			if err != nil {
-//				return nil
-//			}
-//
-//			buf := new(bytes.Buffer)
-//			_, err = buf.WriteString(eventQueueJSONStr)
-//			if err != nil {
-//				return err
-//			}
-//
-//			err = json.Unmarshal(buf.Bytes(), &targetJSON)
-//			if err != nil {
-//				return err
-//			}
-//
-//			targetJSONList = append(targetJSONList, targetJSON)
-//		}
-//		return err
-//	})
-//	return targetJSONList, err
-//}
-//
-//// StoreRoundInfo will store the round info into db
-//func (b *BuntStore) StoreRoundInfo(round uint64, step uint8, methodName, name string) error {
-//	err := b.db.Update(func(tx *buntdb.Tx) error {
-//		eventQueueKey := GetKey(RoundInfoPrefix, fmt.Sprintf("%d:%d", round, step))
-//
-//		eventQueueJSON := RoundInfoJSON{
-//			Step:      step,
-//			UpdatedAt: time.Now(),
-//			Method:    methodName,
-//			Name:      name,
-//		}
-//
-//		eventQueueByteArr, err := json.Marshal(eventQueueJSON)
-//		if err != nil {
-//			return err
-//		}
-//
-//		_, _, err = tx.Set(eventQueueKey, string(eventQueueByteArr), &buntdb.SetOptions{Expires: true, TTL: time.Duration(cfg.Get().API.ExpirationTime) * time.Second})
-//		return err
-//	})
-//	return err
-//}
-//
-//// FetchEventQueue will store the EventQueueJSON info into db
-//func (b *BuntStore) FetchEventQueue(round uint64, stepBegin, stepEnd uint8) ([]EventQueueJSON, error) {
-//	var eventQueueJSONList []EventQueueJSON
-//	err := b.db.View(func(t *buntdb.Tx) error {
-//		var err error
-//		for i := int(stepBegin); i < int(stepEnd); i++ {
-//			var targetJSON EventQueueJSON
-//			key := GetKey(EventQueuePrefix, fmt.Sprintf("%d:%d", round, i))
-//			var eventQueueJSONStr string
-//			eventQueueJSONStr, err = t.Get(key)
-//			if err != nil {
-//				return nil
-//			}
-//
-//			buf := new(bytes.Buffer)
-//			_, err = buf.WriteString(eventQueueJSONStr)
-//			if err != nil {
-//				return err
-//			}
-//
-//			err = json.Unmarshal(buf.Bytes(), &targetJSON)
-//			if err != nil {
-//				return err
-//			}
-//
-//			eventQueueJSONList = append(eventQueueJSONList, targetJSON)
-//		}
-//		return err
-//	})
-//	return eventQueueJSONList, err
-//}
-//
-//// StoreEventQueue will store the round info into db
-//func (b *BuntStore) StoreEventQueue(round uint64, step uint8, m message.Message) error {
-//	err := b.db.Update(func(tx *buntdb.Tx) error {
-//		eventQueueKey := GetKey(EventQueuePrefix, fmt.Sprintf("%d:%d", round, step))
-//
-//		eventQueueJSON := EventQueueJSON{
-//			Round: round,
-//			Step:  step,
-//			//TODO: find out how to use the message field and avoid err: &json.UnmarshalTypeError{Value:"object", Type:(*reflect.rtype)(0xbe6a40), Offset:31, Struct:"EventQueueJSON", Field:"message"}
-//			//Message:   &m,
-//			UpdatedAt: time.Now(),
-//		}
-//
-//		eventQueueByteArr, err := json.Marshal(eventQueueJSON)
-//		if err != nil {
-//			return err
-//		}
-//
-//		_, _, err = tx.Set(eventQueueKey, string(eventQueueByteArr), &buntdb.SetOptions{Expires: true, TTL: time.Duration(cfg.Get().API.ExpirationTime) * time.Second})
-//		return err
-//	})
-//	return err
-//}
+import (
+	"time"
+
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+)
+
+// StoreRoundInfo will store the round info of a consensus step into db
+func (bdb *StormDBInstance) StoreRoundInfo(round uint64, step uint8, methodName, name string) error {
+	roundInfo := RoundInfoJSON{
+		Round:     round,
+		Step:      step,
+		UpdatedAt: time.Now(),
+		Method:    methodName,
+		Name:      name,
+	}
+
+	return bdb.DB.Save(&roundInfo)
+}
+
+// StoreEventQueue will store the amount of events queued for a future round
+// and step, as seen during the current round and step
+func (bdb *StormDBInstance) StoreEventQueue(currentRound uint64, currentStep uint8, round uint64, step uint8, count int) error {
+	eventQueue := EventQueueJSON{
+		Round:        round,
+		Step:         step,
+		Count:        count,
+		CurrentRound: currentRound,
+		CurrentStep:  currentStep,
+		UpdatedAt:    time.Now(),
+	}
+
+	return bdb.DB.Save(&eventQueue)
+}
+
+// StoreQueueMetrics will store the latest metrics of a consensus queue into
+// db, replacing the previous ones
+func (bdb *StormDBInstance) StoreQueueMetrics(metrics QueueMetricsJSON) error {
+	metrics.UpdatedAt = time.Now()
+	return bdb.DB.Save(&metrics)
+}
+
+// StoreBidders will store the bid list of a given height into db
+func (bdb *StormDBInstance) StoreBidders(height uint64, bids []Bid, ownBid *OwnBid) error {
+	bidders := BiddersJSON{
+		ID:     height,
+		Bids:   bids,
+		OwnBid: ownBid,
+	}
+
+	return bdb.Save(&bidders)
+}
+
+// PruneExpired deletes the round info and the event queue snapshots older than
+// the configured API expiration time. A zero expiration time disables pruning
+func (bdb *StormDBInstance) PruneExpired() error {
+	expiration := cfg.Get().API.ExpirationTime
+	if expiration <= 0 {
+		return nil
+	}
+
+	deadline := time.Now().Add(-time.Duration(expiration) * time.Second)
+	if err := bdb.DB.Select(q.Lt("UpdatedAt", deadline)).Delete(&RoundInfoJSON{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	if err := bdb.DB.Select(q.Lt("UpdatedAt", deadline)).Delete(&EventQueueJSON{}); err != nil && err != storm.ErrNotFound {
+		return err
+	}
+
+	return nil
+}
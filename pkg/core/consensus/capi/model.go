@@ -4,17 +4,36 @@ import (
 	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
-
-	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 )
 
-// EventQueueJSON is used as JSON rapper for eventQueue fields
+// EventQueueJSON is used as JSON rapper for eventQueue fields. It is a
+// snapshot of the amount of events queued for a future round and step, taken
+// while the consensus was at CurrentRound and CurrentStep
 type EventQueueJSON struct {
-	ID        int              `storm:"id,increment" json:"id"` // primary key with auto increment
-	Round     uint64           `json:"round"`
-	Step      uint8            `json:"step"`
-	Message   *message.Message `json:"message"`
-	UpdatedAt time.Time        `json:"updated_at"`
+	ID           int       `storm:"id,increment" json:"id"` // primary key with auto increment
+	Round        uint64    `storm:"index" json:"round"`
+	Step         uint8     `json:"step"`
+	Count        int       `json:"count"`
+	CurrentRound uint64    `json:"current_round"`
+	CurrentStep  uint8     `json:"current_step"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// QueueMetricsJSON is used as JSON wrapper for the metrics of a consensus
+// queue. Only the latest snapshot of each queue is kept
+type QueueMetricsJSON struct {
+	Name                   string    `storm:"id" json:"name"`
+	Stored                 uint64    `json:"stored"`
+	Spilled                uint64    `json:"spilled"`
+	SpillEnabled           bool      `json:"spill_enabled"`
+	RejectedTooFarAhead    uint64    `json:"rejected_too_far_ahead"`
+	RejectedRoundFull      uint64    `json:"rejected_round_full"`
+	RejectedStepFull       uint64    `json:"rejected_step_full"`
+	RejectedSenderFull     uint64    `json:"rejected_sender_full"`
+	RejectedNotProvisioner uint64    `json:"rejected_not_provisioner"`
+	RejectedQueueFull      uint64    `json:"rejected_queue_full"`
+	Evicted                uint64    `json:"evicted"`
+	UpdatedAt              time.Time `json:"updated_at"`
 }
 
 // RoundInfoJSON is used as JSON wrapper for round info fields
@@ -60,6 +79,27 @@ type Stake struct {
 	EndHeight   uint64 `json:"end_height"`
 }
 
+// Bid represents a bid transaction included in a block
+type Bid struct {
+	TxID   []byte `json:"txid"`
+	Amount uint64 `json:"amount"`
+	Fee    uint64 `json:"fee"`
+}
+
+// OwnBid represents the bid of this node, as stored in the chain DB. The
+// secret K is never exposed
+type OwnBid struct {
+	D        []byte `json:"d"`
+	BidIndex uint64 `json:"bid_index"`
+}
+
+// BiddersJSON represents the bid list at a given height
+type BiddersJSON struct {
+	ID     uint64  `storm:"id" json:"id"`
+	Bids   []Bid   `json:"bids"`
+	OwnBid *OwnBid `json:"own_bid,omitempty"`
+}
+
 // ProvisionerJSON represents the Provisioner
 type ProvisionerJSON struct {
 	ID      uint64        `storm:"id" json:"id"`
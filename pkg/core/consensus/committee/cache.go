@@ -0,0 +1,189 @@
+package committee
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+)
+
+// DefaultCacheCapacity is the amount of VotingCommittees kept by the shared
+// Cache. With a 64 members committee this amounts to a few MB
+const DefaultCacheCapacity = 2048
+
+var sharedCache = NewCache(DefaultCacheCapacity)
+
+// SharedCache returns the Cache shared by the reduction and agreement
+// handlers and by the block certificate verification
+func SharedCache() *Cache {
+	return sharedCache
+}
+
+type cacheKey struct {
+	setHash [32]byte
+	round   uint64
+	step    uint8
+	size    int
+}
+
+type cacheEntry struct {
+	key       cacheKey
+	committee user.VotingCommittee
+}
+
+// CacheStats are the counters of a Cache
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Evicted uint64
+}
+
+// Cache is a bounded LRU cache of VotingCommittees. Committees are keyed by
+// the hash of the provisioner set they are extracted from (see
+// user.Provisioners.Hash), the round, the step and the committee size, so
+// that the sortition is run only once per (round, step) no matter how many
+// components need the committee. Concurrent requests for a committee being
+// computed wait for its result rather than running the sortition again.
+// The returned VotingCommittees are shared and must not be mutated
+type Cache struct {
+	lock     sync.Mutex
+	capacity int
+	entries  map[cacheKey]*list.Element
+	lru      *list.List
+	pending  map[cacheKey]chan struct{}
+	stats    CacheStats
+}
+
+// NewCache creates a Cache holding up to `capacity` VotingCommittees
+func NewCache(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		entries:  make(map[cacheKey]*list.Element),
+		lru:      list.New(),
+		pending:  make(map[cacheKey]chan struct{}),
+	}
+}
+
+// Committee returns the VotingCommittee of the provisioner set `p`, whose
+// hash is `setHash`, for a given round, step and size. The sortition is run
+// only if the committee is not cached yet
+func (c *Cache) Committee(p user.Provisioners, setHash []byte, round uint64, step uint8, size int) user.VotingCommittee {
+	key := newCacheKey(setHash, round, step, size)
+
+	for {
+		c.lock.Lock()
+		if e, ok := c.entries[key]; ok {
+			c.lru.MoveToFront(e)
+			c.stats.Hits++
+			c.lock.Unlock()
+			return e.Value.(*cacheEntry).committee
+		}
+
+		wait, ok := c.pending[key]
+		if !ok {
+			break
+		}
+
+		// somebody else is running the sortition for this key
+		c.lock.Unlock()
+		<-wait
+	}
+
+	c.stats.Misses++
+	done := make(chan struct{})
+	c.pending[key] = done
+	c.lock.Unlock()
+
+	return c.compute(p, key, done)
+}
+
+// Precompute runs in the background the sortition of the `amount` steps
+// following `step` which are neither cached nor being computed
+func (c *Cache) Precompute(p user.Provisioners, setHash []byte, round uint64, step uint8, amount uint8, size int) {
+	keys := make([]cacheKey, 0, amount)
+	dones := make([]chan struct{}, 0, amount)
+
+	c.lock.Lock()
+	for i := uint8(1); i <= amount; i++ {
+		next := int(step) + int(i)
+		if next >= maxStep {
+			break
+		}
+
+		key := newCacheKey(setHash, round, uint8(next), size)
+		if _, ok := c.entries[key]; ok {
+			continue
+		}
+
+		if _, ok := c.pending[key]; ok {
+			continue
+		}
+
+		done := make(chan struct{})
+		c.pending[key] = done
+		keys = append(keys, key)
+		dones = append(dones, done)
+	}
+	c.lock.Unlock()
+
+	if len(keys) == 0 {
+		return
+	}
+
+	go func() {
+		for i, key := range keys {
+			c.compute(p, key, dones[i])
+		}
+	}()
+}
+
+// Stats returns the counters of the Cache
+func (c *Cache) Stats() CacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.stats
+}
+
+// Len returns the amount of cached VotingCommittees
+func (c *Cache) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lru.Len()
+}
+
+// compute runs the sortition for a key marked as pending, stores the result
+// and wakes up whoever waits for it. The waiters are woken up even if the
+// sortition panics, in which case they run it themselves
+func (c *Cache) compute(p user.Provisioners, key cacheKey, done chan struct{}) user.VotingCommittee {
+	defer func() {
+		c.lock.Lock()
+		delete(c.pending, key)
+		close(done)
+		c.lock.Unlock()
+	}()
+
+	committee := p.CreateVotingCommittee(key.round, key.step, key.size)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries[key] = c.lru.PushFront(&cacheEntry{key, committee})
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+		c.stats.Evicted++
+	}
+
+	return committee
+}
+
+func newCacheKey(setHash []byte, round uint64, step uint8, size int) cacheKey {
+	key := cacheKey{
+		round: round,
+		step:  step,
+		size:  size,
+	}
+
+	copy(key.setHash[:], setHash)
+	return key
+}
@@ -0,0 +1,99 @@
+package committee_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCacheCommittee tests that the Cache returns the same committee as the
+// sortition, and runs the sortition only once per key
+func TestCacheCommittee(t *testing.T) {
+	p, _ := consensus.MockProvisioners(10)
+	setHash, err := p.Hash()
+	require.NoError(t, err)
+
+	c := committee.NewCache(10)
+	expected := p.CreateVotingCommittee(1, 2, 10)
+	got := c.Committee(*p, setHash, 1, 2, 10)
+	assert.True(t, expected.Equal(&got))
+
+	c.Committee(*p, setHash, 1, 2, 10)
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Hits)
+
+	// a different provisioner set is a different key
+	other, _ := consensus.MockProvisioners(10)
+	otherHash, err := other.Hash()
+	require.NoError(t, err)
+	c.Committee(*other, otherHash, 1, 2, 10)
+	assert.Equal(t, uint64(2), c.Stats().Misses)
+}
+
+// TestCacheBounded tests that the least recently used committees are evicted
+func TestCacheBounded(t *testing.T) {
+	p, _ := consensus.MockProvisioners(5)
+	setHash, err := p.Hash()
+	require.NoError(t, err)
+
+	c := committee.NewCache(3)
+	for step := uint8(1); step <= 5; step++ {
+		c.Committee(*p, setHash, 1, step, 5)
+	}
+
+	assert.Equal(t, 3, c.Len())
+	assert.Equal(t, uint64(2), c.Stats().Evicted)
+
+	// step 1 got evicted, step 5 did not
+	c.Committee(*p, setHash, 1, 5, 5)
+	assert.Equal(t, uint64(1), c.Stats().Hits)
+	c.Committee(*p, setHash, 1, 1, 5)
+	assert.Equal(t, uint64(6), c.Stats().Misses)
+}
+
+// TestCachePrecompute tests that the committees of the following steps are
+// computed in the background
+func TestCachePrecompute(t *testing.T) {
+	p, _ := consensus.MockProvisioners(5)
+	setHash, err := p.Hash()
+	require.NoError(t, err)
+
+	c := committee.NewCache(10)
+	c.Precompute(*p, setHash, 1, 1, 3, 5)
+	assert.Eventually(t, func() bool {
+		return c.Len() == 3
+	}, time.Second, 10*time.Millisecond)
+
+	for step := uint8(2); step <= 4; step++ {
+		c.Committee(*p, setHash, 1, step, 5)
+	}
+
+	assert.Equal(t, uint64(3), c.Stats().Hits)
+}
+
+// BenchmarkHandlerCommittee measures the committee extraction for a large
+// provisioner set, as performed for each agreement message
+func BenchmarkHandlerCommittee(b *testing.B) {
+	p, ks := consensus.MockProvisioners(500)
+	h := committee.NewHandler(ks[0], *p)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Committee(1, uint8(1+i%3), 64)
+	}
+}
+
+// BenchmarkSortition measures the same extraction without the Cache
+func BenchmarkSortition(b *testing.B) {
+	p, _ := consensus.MockProvisioners(500)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.CreateVotingCommittee(1, uint8(1+i%3), 64)
+	}
+}
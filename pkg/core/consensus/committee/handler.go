@@ -2,32 +2,45 @@ package committee
 
 import (
 	"math"
-	"sync"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	log "github.com/sirupsen/logrus"
 )
 
-// PregenerationAmount is the size of a pregenerated committee
+var lg = log.WithField("process", "committee")
+
+// PregenerationAmount is the amount of committees following the requested
+// one which are computed in the background
 var PregenerationAmount uint8 = 8
 
+// maxStep is the step at which the consensus halts
+const maxStep = math.MaxUint8
+
 // Handler is injected in the consensus components that work with the various
-// committee. It generates and maintains a list of active and valid committee members and
-// handle the votes
+// committee. It extracts the committees of its provisioner set through the
+// shared Cache and handles the votes
 type Handler struct {
 	key.Keys
 	Provisioners user.Provisioners
-	Committees   []user.VotingCommittee
-	lock         sync.RWMutex
+	cache        *Cache
+	setHash      []byte
 }
 
-// NewHandler creates a new committee.Handler by instantiating the committee
-// slice, setting the keys and setting the Provisioner set
+// NewHandler creates a new committee.Handler by setting the keys and the
+// Provisioner set, and binding it to the SharedCache
 func NewHandler(keys key.Keys, p user.Provisioners) *Handler {
+	setHash, err := p.Hash()
+	if err != nil {
+		// without a set hash, the committees cannot be cached
+		lg.WithError(err).Warn("could not hash the provisioner set")
+	}
+
 	return &Handler{
 		Keys:         keys,
-		Committees:   make([]user.VotingCommittee, math.MaxUint8),
 		Provisioners: p,
+		cache:        SharedCache(),
+		setHash:      setHash,
 	}
 }
 
@@ -47,50 +60,30 @@ func (b *Handler) VotesFor(pubKeyBLS []byte, round uint64, step uint8, maxSize i
 	return b.Committee(round, step, maxSize).OccurrencesOf(pubKeyBLS)
 }
 
-// Committee returns a VotingCommittee for a given round and step.
+// Committee returns a VotingCommittee for a given round and step. The
+// committees of the following steps are precomputed in the background
 func (b *Handler) Committee(round uint64, step uint8, maxSize int) user.VotingCommittee {
-	if b.membersAt(step) == 0 {
-		b.generateCommittees(round, step, maxSize)
+	if step == maxStep {
+		panic("Consensus reached max steps")
 	}
-	b.lock.RLock()
-	committee := b.Committees[step]
-	b.lock.RUnlock()
-	return committee
-}
 
-func (b *Handler) generateCommittees(round uint64, step uint8, maxSize int) {
 	size := b.CommitteeSize(round, maxSize)
-
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	committees := b.Provisioners.GenerateCommittees(round, PregenerationAmount, step, size)
-	for i, committee := range committees {
-		if step == math.MaxUint8 {
-			panic("Consensus reached max steps")
-		}
-		b.Committees[int(step)+i] = committee
+	if b.setHash == nil {
+		return b.Provisioners.CreateVotingCommittee(round, step, size)
 	}
+
+	committee := b.cache.Committee(b.Provisioners, b.setHash, round, step, size)
+	b.cache.Precompute(b.Provisioners, b.setHash, round, step, PregenerationAmount, size)
+	return committee
 }
 
 // CommitteeSize returns the size of a VotingCommittee, depending on
 // how many provisioners are in the set.
 func (b *Handler) CommitteeSize(round uint64, maxSize int) int {
-	b.lock.RLock()
 	size := b.Provisioners.SubsetSizeAt(round)
-	b.lock.RUnlock()
 	if size > maxSize {
 		return maxSize
 	}
 
 	return size
 }
-
-func (b *Handler) membersAt(idx uint8) int {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if idx == math.MaxUint8 {
-		panic("Consensus reached max steps")
-	}
-	return b.Committees[idx].Set.Len()
-}
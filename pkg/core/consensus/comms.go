@@ -7,6 +7,7 @@ import (
 	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -15,7 +16,6 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
-	"github.com/dusk-network/dusk-crypto/bls"
 )
 
 type (
@@ -23,6 +23,12 @@ type (
 	// after the conclusion of the first reduction step.
 	CandidateVerificationFunc func(block.Block) error
 
+	// CandidatePreparationFunc is a callback notified of the hash of the
+	// expected winner of a round, once the second reduction step reached a
+	// quorum on it, so that its acceptance can be prepared ahead of the
+	// Agreement certificate
+	CandidatePreparationFunc func(hash []byte)
+
 	// Emitter is a simple struct to pass the communication channels that the steps should be
 	// able to emit onto
 	Emitter struct {
@@ -31,6 +37,14 @@ type (
 		Keys        key.Keys
 		Proxy       transactions.Proxy
 		TimerLength time.Duration
+		// Status records what the consensus is doing. It can be nil
+		Status *StatusTracker
+		// Signer signs the consensus messages. If nil, the Keys are used
+		// directly
+		Signer signer.Signer
+		// CandidatePreparation is notified of the expected winners. It can
+		// be nil
+		CandidatePreparation CandidatePreparationFunc
 	}
 
 	// RoundUpdate carries the data about the new Round, such as the active
@@ -88,19 +102,55 @@ func (c *acceptedBlockCollector) Collect(m message.Message) {
 	c.blockChan <- m.Payload().(block.Block)
 }
 
-// Sign a header
+// Sign the header of a reduction vote
 func (e *Emitter) Sign(h header.Header) ([]byte, error) {
+	return e.signHeader(signer.Vote, h)
+}
+
+// SignAgreement signs the header of an agreement
+func (e *Emitter) SignAgreement(h header.Header) ([]byte, error) {
+	return e.signHeader(signer.Agreement, h)
+}
+
+func (e *Emitter) signHeader(domain signer.Domain, h header.Header) ([]byte, error) {
 	preimage := new(bytes.Buffer)
 	if err := header.MarshalSignableVote(preimage, h); err != nil {
 		return nil, err
 	}
 
-	signedHash, err := bls.Sign(e.Keys.BLSSecretKey, e.Keys.BLSPubKey, preimage.Bytes())
-	if err != nil {
-		return nil, err
+	return e.signer().Sign(signer.Request{
+		Domain:  domain,
+		Round:   h.Round,
+		Step:    h.Step,
+		Payload: preimage.Bytes(),
+	})
+}
+
+// SignSeed signs the seed of a round, for the block generation at the given
+// step
+func (e *Emitter) SignSeed(round uint64, step uint8, seed []byte) ([]byte, error) {
+	return e.signer().Sign(signer.Request{
+		Domain:  signer.Seed,
+		Round:   round,
+		Step:    step,
+		Payload: seed,
+	})
+}
+
+func (e *Emitter) signer() signer.Signer {
+	if e.Signer == nil {
+		return signer.NewLocal(e.Keys)
 	}
 
-	return signedHash.Compress(), nil
+	return e.Signer
+}
+
+// PrepareCandidate notifies the CandidatePreparation callback, if any, of the
+// expected winner of the round
+func (e *Emitter) PrepareCandidate(hash []byte) {
+	if e.CandidatePreparation != nil {
+		e.CandidatePreparation(hash)
+	}
 }
 
 // Gossip concatenates the topic, the header and the payload,
@@ -0,0 +1,154 @@
+package participation
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// Stats is the participation of a provisioner to the reduction committees
+// certified by the accepted blocks. A provisioner is counted once per step it
+// got selected for, regardless of how many seats it has in the committee
+type Stats struct {
+	PublicKeyBLS []byte `json:"bls-public-key"`
+	// Selected is the amount of certified steps the provisioner was a
+	// committee member of
+	Selected uint64 `json:"selected"`
+	// Voted is the amount of those steps where its vote made it into the
+	// certificate
+	Voted uint64 `json:"voted"`
+	// Missed is the amount of those steps where it did not
+	Missed       uint64 `json:"missed"`
+	LastSelected uint64 `json:"last-selected"`
+	LastVoted    uint64 `json:"last-voted"`
+}
+
+// Tracker decodes the committee bitsets of the block certificates and keeps
+// the participation Stats of each provisioner. It is thread-safe
+type Tracker struct {
+	lock  sync.RWMutex
+	stats map[string]*Stats
+}
+
+// NewTracker creates an empty Tracker
+func NewTracker() *Tracker {
+	return &Tracker{stats: make(map[string]*Stats)}
+}
+
+// Record rebuilds the two reduction committees certified by the block, using
+// the provisioner set the certificate was produced with, and updates the
+// Stats of their members
+func (t *Tracker) Record(p user.Provisioners, blk block.Block) error {
+	// the genesis block and the first block carry no meaningful certificate
+	if blk.Header.Height < 2 || blk.Header.Certificate == nil {
+		return nil
+	}
+
+	setHash, err := p.Hash()
+	if err != nil {
+		return err
+	}
+
+	round := blk.Header.Height
+	cert := blk.Header.Certificate
+	size := p.SubsetSizeAt(round)
+	if size > agreement.MaxCommitteeSize {
+		size = agreement.MaxCommitteeSize
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.recordStep(p, setHash, round, cert.Step-1, size, cert.StepOneCommittee)
+	t.recordStep(p, setHash, round, cert.Step, size, cert.StepTwoCommittee)
+	return nil
+}
+
+func (t *Tracker) recordStep(p user.Provisioners, setHash []byte, round uint64, step uint8, size int, bitSet uint64) {
+	votingCommittee := committee.SharedCache().Committee(p, setHash, round, step, size)
+	voters := votingCommittee.IntersectCluster(bitSet)
+
+	for _, k := range votingCommittee.Set {
+		pk := k.Bytes()
+		s, ok := t.stats[string(pk)]
+		if !ok {
+			s = &Stats{PublicKeyBLS: pk}
+			t.stats[string(pk)] = s
+		}
+
+		s.Selected++
+		s.LastSelected = round
+		if _, voted := voters.IndexOf(pk); voted {
+			s.Voted++
+			s.LastVoted = round
+		} else {
+			s.Missed++
+		}
+	}
+}
+
+// Get returns the Stats of a provisioner. The second return value is false
+// if it was never selected in a committee
+func (t *Tracker) Get(pubKeyBLS []byte) (Stats, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	s, ok := t.stats[string(pubKeyBLS)]
+	if !ok {
+		return Stats{}, false
+	}
+
+	return *s, true
+}
+
+// All returns the Stats of all provisioners, sorted by public key
+func (t *Tracker) All() []Stats {
+	t.lock.RLock()
+	all := make([]Stats, 0, len(t.stats))
+	for _, s := range t.stats {
+		all = append(all, *s)
+	}
+	t.lock.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		return bytes.Compare(all[i].PublicKeyBLS, all[j].PublicKeyBLS) < 0
+	})
+	return all
+}
+
+// Listen serves the topics.GetParticipation requests on the RPCBus. The
+// request parameter is an optional BLS public key: without it, the Stats of
+// all provisioners are returned
+func (t *Tracker) Listen(rpcBus *rpcbus.RPCBus) error {
+	reqChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetParticipation, reqChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(t.lookup(r.Params), nil)
+		}
+	}()
+
+	return nil
+}
+
+func (t *Tracker) lookup(params interface{}) []Stats {
+	pk, ok := params.([]byte)
+	if !ok || len(pk) == 0 {
+		return t.All()
+	}
+
+	s, found := t.Get(pk)
+	if !found {
+		return []Stats{}
+	}
+
+	return []Stats{s}
+}
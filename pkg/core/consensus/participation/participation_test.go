@@ -0,0 +1,89 @@
+package participation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRecord tests that the members of the certified committees are counted
+// as voters or absentees according to the certificate bitsets
+func TestRecord(t *testing.T) {
+	p, _ := consensus.MockProvisioners(10)
+	blk := helper.RandomBlock(5, 1)
+	blk.Header.Certificate = block.EmptyCertificate()
+	blk.Header.Certificate.Step = 3
+
+	stepOne := p.CreateVotingCommittee(5, 2, 10)
+	stepTwo := p.CreateVotingCommittee(5, 3, 10)
+
+	// everybody votes in the first step, only the first member in the second
+	blk.Header.Certificate.StepOneCommittee = stepOne.Bits(stepOne.Set)
+	voter := stepTwo.Set[0].Bytes()
+	blk.Header.Certificate.StepTwoCommittee = stepTwo.Bits(sortedset.Set{stepTwo.Set[0]})
+
+	tracker := participation.NewTracker()
+	require.NoError(t, tracker.Record(*p, *blk))
+
+	var selected, voted, missed uint64
+	for _, s := range tracker.All() {
+		selected += s.Selected
+		voted += s.Voted
+		missed += s.Missed
+		assert.Equal(t, s.Selected, s.Voted+s.Missed)
+		assert.Equal(t, uint64(5), s.LastSelected)
+	}
+
+	assert.Equal(t, uint64(len(stepOne.Set)+len(stepTwo.Set)), selected)
+	assert.Equal(t, uint64(len(stepOne.Set)+1), voted)
+	assert.Equal(t, uint64(len(stepTwo.Set)-1), missed)
+
+	s, ok := tracker.Get(voter)
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), s.LastVoted)
+}
+
+// TestRecordSkipsEarlyBlocks tests that blocks without a meaningful
+// certificate are ignored
+func TestRecordSkipsEarlyBlocks(t *testing.T) {
+	p, _ := consensus.MockProvisioners(3)
+	tracker := participation.NewTracker()
+	require.NoError(t, tracker.Record(*p, *helper.RandomBlock(1, 1)))
+	assert.Empty(t, tracker.All())
+}
+
+// TestParticipationOverRPCBus tests that the Stats can be requested through
+// the RPCBus, for all provisioners or for a single one
+func TestParticipationOverRPCBus(t *testing.T) {
+	p, _ := consensus.MockProvisioners(5)
+	blk := helper.RandomBlock(2, 1)
+	blk.Header.Certificate = block.EmptyCertificate()
+	blk.Header.Certificate.Step = 3
+
+	rb := rpcbus.New()
+	tracker := participation.NewTracker()
+	require.NoError(t, tracker.Listen(rb))
+	require.NoError(t, tracker.Record(*p, *blk))
+
+	resp, err := rb.Call(topics.GetParticipation, rpcbus.EmptyRequest(), time.Second)
+	require.NoError(t, err)
+	all := resp.([]participation.Stats)
+	require.NotEmpty(t, all)
+
+	resp, err = rb.Call(topics.GetParticipation, rpcbus.NewRequest(all[0].PublicKeyBLS), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, all[:1], resp.([]participation.Stats))
+
+	resp, err = rb.Call(topics.GetParticipation, rpcbus.NewRequest([]byte{1, 2, 3}), time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, resp.([]participation.Stats))
+}
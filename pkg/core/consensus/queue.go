@@ -1,77 +1,504 @@
 package consensus
 
 import (
+	"errors"
+	"path/filepath"
+	"sort"
 	"sync"
 
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
 )
 
+var (
+	// ErrTooFarAhead is returned when a message refers to a round beyond the
+	// look-ahead window of the Queue
+	ErrTooFarAhead = errors.New("message round is too far ahead")
+	// ErrRoundFull is returned when the Queue holds the maximum amount of
+	// messages allowed for a round
+	ErrRoundFull = errors.New("queue is full for this round")
+	// ErrStepFull is returned when the Queue holds the maximum amount of
+	// messages allowed for a step
+	ErrStepFull = errors.New("queue is full for this step")
+	// ErrSenderFull is returned when a sender already queued the maximum
+	// amount of messages allowed for a round
+	ErrSenderFull = errors.New("sender exceeded its quota for this round")
+	// ErrNotProvisioner is returned when the sender of a message is not part
+	// of the provisioner set of the Queue
+	ErrNotProvisioner = errors.New("sender is not a provisioner")
+	// ErrQueueFull is returned when the Queue memory budget is exhausted and
+	// no spill storage is available
+	ErrQueueFull = errors.New("queue is full")
+)
+
+// Default limits of the Queue. They are used whenever the corresponding
+// configuration is left unset
+const (
+	DefaultMaxRoundsAhead = 10
+	DefaultMaxPerRound    = 10000
+	DefaultMaxPerStep     = 1000
+	DefaultMaxPerSender   = 64
+	DefaultMaxInMemory    = 50000
+)
+
+// QueueLimits bounds the amount of messages a Queue accepts. A zero value
+// disables the corresponding limit
+type QueueLimits struct {
+	// MaxRoundsAhead is the look-ahead window relative to the current round
+	MaxRoundsAhead uint64
+	// MaxPerRound is the maximum amount of messages stored for a single round
+	MaxPerRound int
+	// MaxPerStep is the maximum amount of messages stored for a single step
+	MaxPerStep int
+	// MaxPerSender is the maximum amount of messages a single provisioner
+	// can have stored for a round
+	MaxPerSender int
+	// MaxInMemory is the maximum amount of messages kept in memory. Messages
+	// exceeding it are spilled to disk if a SpillDir is configured, or
+	// rejected otherwise
+	MaxInMemory int
+	// SpillDir is the directory of the on-disk spill storage. Empty disables
+	// spilling. The directory is wiped when opened, and cannot be shared by
+	// two Queues
+	SpillDir string
+}
+
+// DefaultQueueLimits returns the QueueLimits used when nothing is configured
+func DefaultQueueLimits() QueueLimits {
+	return QueueLimits{
+		MaxRoundsAhead: DefaultMaxRoundsAhead,
+		MaxPerRound:    DefaultMaxPerRound,
+		MaxPerStep:     DefaultMaxPerStep,
+		MaxPerSender:   DefaultMaxPerSender,
+		MaxInMemory:    DefaultMaxInMemory,
+	}
+}
+
+// QueueLimitsFromConfig creates the QueueLimits out of the [consensus.queue]
+// configuration, falling back to the defaults for unset values
+func QueueLimitsFromConfig() QueueLimits {
+	c := cfg.Get().Consensus.Queue
+	l := DefaultQueueLimits()
+
+	if c.MaxRoundsAhead > 0 {
+		l.MaxRoundsAhead = c.MaxRoundsAhead
+	}
+
+	if c.MaxPerRound > 0 {
+		l.MaxPerRound = c.MaxPerRound
+	}
+
+	if c.MaxPerStep > 0 {
+		l.MaxPerStep = c.MaxPerStep
+	}
+
+	if c.MaxPerSender > 0 {
+		l.MaxPerSender = c.MaxPerSender
+	}
+
+	if c.MaxInMemory > 0 {
+		l.MaxInMemory = c.MaxInMemory
+	}
+
+	l.SpillDir = c.SpillDir
+	return l
+}
+
+// QueueMetrics is a snapshot of the Queue counters
+type QueueMetrics struct {
+	// Stored is the amount of messages currently held (in memory or spilled)
+	Stored uint64
+	// Spilled is the amount of messages currently held on disk
+	Spilled uint64
+	// RejectedTooFarAhead counts messages rejected for being beyond the
+	// look-ahead window
+	RejectedTooFarAhead uint64
+	// RejectedRoundFull counts messages rejected by the per-round limit
+	RejectedRoundFull uint64
+	// RejectedStepFull counts messages rejected by the per-step limit
+	RejectedStepFull uint64
+	// RejectedSenderFull counts messages rejected by the per-sender limit
+	RejectedSenderFull uint64
+	// RejectedNotProvisioner counts messages rejected because their sender
+	// is not a provisioner
+	RejectedNotProvisioner uint64
+	// RejectedQueueFull counts messages rejected because the memory budget
+	// was exhausted
+	RejectedQueueFull uint64
+	// Evicted counts messages dropped because their round became obsolete
+	Evicted uint64
+	// SpillEnabled tells if the messages exceeding the memory budget are
+	// spilled to disk
+	SpillEnabled bool
+}
+
+type queueCounters struct {
+	rejectedTooFarAhead    uint64
+	rejectedRoundFull      uint64
+	rejectedStepFull       uint64
+	rejectedSenderFull     uint64
+	rejectedQueueFull      uint64
+	evicted                uint64
+	rejectedNotProvisioner uint64
+}
+
+// roundEntry holds the messages of a round together with the counters used
+// to enforce the Queue limits
+type roundEntry struct {
+	steps     map[uint8][]message.Message
+	total     int
+	perStep   map[uint8]int
+	perSender map[string]int
+	spilled   map[uint8]int
+}
+
+func newRoundEntry() *roundEntry {
+	return &roundEntry{
+		steps:     make(map[uint8][]message.Message),
+		perStep:   make(map[uint8]int),
+		perSender: make(map[string]int),
+		spilled:   make(map[uint8]int),
+	}
+}
+
 // Queue is a Queue of Events grouped by rounds and steps. It is thread-safe
 // through a sync.RWMutex.
-//TODO: entries should become buntdb instead
+// The Queue is bounded: it only accepts messages within a look-ahead window
+// from the current round, and caps the amount of messages per round, per step
+// and per sender. Rounds older than the current one are evicted.
+// As the sender of a message is not authenticated until the message is
+// processed, the Queue only accepts the messages of the provisioners set
+// through SetProvisioners, so that the per-sender quota cannot be dodged by
+// forging senders.
 type Queue struct {
 	lock    sync.RWMutex
-	entries map[uint64]map[uint8][]message.Message
+	entries map[uint64]*roundEntry
+	limits  QueueLimits
+
+	// provisioners the senders are checked against. Nil accepts any sender
+	provisioners *user.Provisioners
+
+	// current is the most recent round the Queue has been queried for
+	current  uint64
+	inMemory int
+	spilled  int
+	spill    *spillStore
+
+	counters queueCounters
 }
 
 // NewQueue creates a new Queue. It is primarily used by Collectors to
 // temporarily store messages not yet relevant to the collection process.
+// The limits are taken from the configuration, except for the spill storage
+// which is left disabled. Use NewSpillingQueue to enable it.
 func NewQueue() *Queue {
-	entries := make(map[uint64]map[uint8][]message.Message)
-	return &Queue{
-		entries: entries,
+	limits := QueueLimitsFromConfig()
+	limits.SpillDir = ""
+	return NewQueueWithLimits(limits)
+}
+
+// NewSpillingQueue creates a new Queue with the limits taken from the
+// configuration. If spilling is configured, the Queue spills into the `name`
+// subdirectory of the configured SpillDir, so that each Queue owns its
+// storage
+func NewSpillingQueue(name string) *Queue {
+	limits := QueueLimitsFromConfig()
+	if limits.SpillDir != "" {
+		limits.SpillDir = filepath.Join(limits.SpillDir, name)
 	}
+
+	return NewQueueWithLimits(limits)
+}
+
+// NewQueueWithLimits creates a new Queue bounded by the given QueueLimits. If
+// the spill storage cannot be opened, the Queue falls back to memory only and
+// reports it through Metrics
+func NewQueueWithLimits(limits QueueLimits) *Queue {
+	q := &Queue{
+		entries: make(map[uint64]*roundEntry),
+		limits:  limits,
+	}
+
+	if limits.SpillDir != "" {
+		s, err := newSpillStore(limits.SpillDir)
+		if err != nil {
+			log.
+				WithError(err).
+				WithField("dir", limits.SpillDir).
+				Error("could not open queue spill storage, keeping messages in memory only")
+		} else {
+			q.spill = s
+		}
+	}
+
+	return q
 }
 
 // GetEvents returns the events for a round and step.
 func (eq *Queue) GetEvents(round uint64, step uint8) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
-	if eq.entries[round][step] != nil {
-		messages := eq.entries[round][step]
-		eq.entries[round][step] = nil
-		return messages
+	eq.advance(round)
+
+	entry := eq.entries[round]
+	if entry == nil {
+		return nil
 	}
 
-	return nil
+	messages := entry.steps[step]
+	delete(entry.steps, step)
+	eq.inMemory -= len(messages)
+
+	if n := entry.spilled[step]; n > 0 {
+		messages = append(messages, eq.spill.get(round, step)...)
+		delete(entry.spilled, step)
+		eq.spilled -= n
+	}
+
+	return messages
+}
+
+// SetProvisioners sets the provisioner set the senders of the messages are
+// checked against. It is the set of the current round, as the one of the
+// future rounds is not known yet. The messages queued already are kept
+func (eq *Queue) SetProvisioners(p user.Provisioners) {
+	eq.lock.Lock()
+	defer eq.lock.Unlock()
+	eq.provisioners = &p
 }
 
-// PutEvent stores an Event at a given round and step.
-func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) {
+// PutEvent stores an Event at a given round and step. It returns an error if
+// the message exceeds any of the Queue limits, or if its sender is not a
+// provisioner, in which case the message is discarded
+func (eq *Queue) PutEvent(round uint64, step uint8, m message.Message) error {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
 
-	// Initialize the map on this round if it was not yet created
-	if eq.entries[round] == nil {
-		eq.entries[round] = make(map[uint8][]message.Message)
+	if eq.limits.MaxRoundsAhead > 0 && round > eq.current+eq.limits.MaxRoundsAhead {
+		eq.counters.rejectedTooFarAhead++
+		return ErrTooFarAhead
+	}
+
+	sender := senderOf(m)
+	if eq.provisioners != nil && eq.provisioners.GetMember([]byte(sender)) == nil {
+		eq.counters.rejectedNotProvisioner++
+		return ErrNotProvisioner
+	}
+
+	// Initialize the entry on this round if it was not yet created
+	entry := eq.entries[round]
+	if entry == nil {
+		entry = newRoundEntry()
+	}
+
+	if eq.limits.MaxPerRound > 0 && entry.total >= eq.limits.MaxPerRound {
+		eq.counters.rejectedRoundFull++
+		return ErrRoundFull
+	}
+
+	if eq.limits.MaxPerStep > 0 && entry.perStep[step] >= eq.limits.MaxPerStep {
+		eq.counters.rejectedStepFull++
+		return ErrStepFull
 	}
 
-	// Initialize the array on this step if it was not yet created
-	if eq.entries[round][step] == nil {
-		eq.entries[round][step] = make([]message.Message, 0)
+	if eq.limits.MaxPerSender > 0 && entry.perSender[sender] >= eq.limits.MaxPerSender {
+		eq.counters.rejectedSenderFull++
+		return ErrSenderFull
 	}
 
-	eq.entries[round][step] = append(eq.entries[round][step], m)
+	if eq.limits.MaxInMemory > 0 && eq.inMemory >= eq.limits.MaxInMemory {
+		if eq.spill == nil || eq.spill.put(round, step, m) != nil {
+			eq.counters.rejectedQueueFull++
+			return ErrQueueFull
+		}
+
+		entry.spilled[step]++
+		eq.spilled++
+	} else {
+		entry.steps[step] = append(entry.steps[step], m)
+		eq.inMemory++
+	}
+
+	entry.total++
+	entry.perStep[step]++
+	entry.perSender[sender]++
+	eq.entries[round] = entry
+	return nil
 }
 
 // Clear the queue.
 func (eq *Queue) Clear(round uint64) {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
-	eq.entries[round] = nil
+	eq.advance(round)
+	eq.drop(round)
 }
 
 // Flush all events stored for a specific round from the queue, and return them.
 func (eq *Queue) Flush(round uint64) []message.Message {
 	eq.lock.Lock()
 	defer eq.lock.Unlock()
-	if eq.entries[round] != nil {
-		events := make([]message.Message, 0)
-		for step, evs := range eq.entries[round] {
-			events = append(events, evs...)
-			eq.entries[round][step] = nil
+	eq.advance(round)
+
+	entry := eq.entries[round]
+	if entry == nil {
+		return nil
+	}
+
+	events := make([]message.Message, 0, entry.total)
+	for step, evs := range entry.steps {
+		events = append(events, evs...)
+		eq.inMemory -= len(evs)
+		delete(entry.steps, step)
+	}
+
+	for step, n := range entry.spilled {
+		events = append(events, eq.spill.get(round, step)...)
+		eq.spilled -= n
+		delete(entry.spilled, step)
+	}
+
+	return events
+}
+
+// Metrics returns a snapshot of the Queue counters
+func (eq *Queue) Metrics() QueueMetrics {
+	eq.lock.RLock()
+	defer eq.lock.RUnlock()
+
+	return QueueMetrics{
+		Stored:                 uint64(eq.inMemory + eq.spilled),
+		Spilled:                uint64(eq.spilled),
+		RejectedTooFarAhead:    eq.counters.rejectedTooFarAhead,
+		RejectedRoundFull:      eq.counters.rejectedRoundFull,
+		RejectedStepFull:       eq.counters.rejectedStepFull,
+		RejectedSenderFull:     eq.counters.rejectedSenderFull,
+		RejectedQueueFull:      eq.counters.rejectedQueueFull,
+		Evicted:                eq.counters.evicted,
+		SpillEnabled:           eq.spill != nil,
+		RejectedNotProvisioner: eq.counters.rejectedNotProvisioner,
+	}
+}
+
+// QueuedEvents is the amount of events stored for a round and step
+type QueuedEvents struct {
+	Round uint64
+	Step  uint8
+	Count int
+}
+
+// Snapshot returns the amount of events currently stored per round and step,
+// sorted by round and step
+func (eq *Queue) Snapshot() []QueuedEvents {
+	eq.lock.RLock()
+	defer eq.lock.RUnlock()
+
+	snapshot := make([]QueuedEvents, 0)
+	for round, entry := range eq.entries {
+		counts := make(map[uint8]int)
+		for step, evs := range entry.steps {
+			counts[step] += len(evs)
+		}
+
+		for step, n := range entry.spilled {
+			counts[step] += n
+		}
+
+		for step, n := range counts {
+			if n > 0 {
+				snapshot = append(snapshot, QueuedEvents{round, step, n})
+			}
 		}
-		return events
 	}
-	return nil
+
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Round != snapshot[j].Round {
+			return snapshot[i].Round < snapshot[j].Round
+		}
+		return snapshot[i].Step < snapshot[j].Step
+	})
+	return snapshot
+}
+
+// Close releases the spill storage, if any
+func (eq *Queue) Close() error {
+	eq.lock.Lock()
+	defer eq.lock.Unlock()
+	if eq.spill == nil {
+		return nil
+	}
+
+	err := eq.spill.close()
+	eq.spill = nil
+	return err
+}
+
+// advance moves the current round forward and evicts all the obsolete
+// rounds. It should be called with the lock held
+func (eq *Queue) advance(round uint64) {
+	if round <= eq.current {
+		return
+	}
+
+	eq.current = round
+	evicted := uint64(0)
+	for r, entry := range eq.entries {
+		if r >= round {
+			continue
+		}
+
+		for _, evs := range entry.steps {
+			evicted += uint64(len(evs))
+		}
+
+		for _, n := range entry.spilled {
+			evicted += uint64(n)
+		}
+
+		eq.drop(r)
+	}
+
+	if evicted > 0 {
+		eq.counters.evicted += evicted
+		log.
+			WithField("round", round).
+			WithField("evicted", evicted).
+			Debugln("evicted obsolete events from queue")
+	}
+}
+
+// drop deletes all messages stored for a round. It should be called with the
+// lock held
+func (eq *Queue) drop(round uint64) {
+	entry := eq.entries[round]
+	if entry == nil {
+		return
+	}
+
+	for _, evs := range entry.steps {
+		eq.inMemory -= len(evs)
+	}
+
+	if len(entry.spilled) > 0 {
+		for _, n := range entry.spilled {
+			eq.spilled -= n
+		}
+
+		eq.spill.deleteRound(round)
+	}
+
+	delete(eq.entries, round)
+}
+
+// senderOf returns the BLS public key of the message sender, if the payload
+// carries a consensus header
+func senderOf(m message.Message) string {
+	p, ok := m.Payload().(InternalPacket)
+	if !ok {
+		return ""
+	}
+
+	return string(p.State().PubKeyBLS)
 }
@@ -0,0 +1,106 @@
+package consensus
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	log "github.com/sirupsen/logrus"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// spillStore keeps the Queue messages which do not fit in memory on disk.
+// Keys are composed as round (8 bytes) | step (1 byte) | sequence (8 bytes),
+// so that all messages of a round or step can be iterated by prefix
+type spillStore struct {
+	db  *leveldb.DB
+	seq uint64
+}
+
+func newSpillStore(dir string) (*spillStore, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// anything left over from a previous run is obsolete
+	s := &spillStore{db: db}
+	s.deleteRange(nil)
+	return s, nil
+}
+
+func (s *spillStore) put(round uint64, step uint8, m message.Message) error {
+	buf, err := message.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	s.seq++
+	key := make([]byte, 17)
+	binary.BigEndian.PutUint64(key[0:8], round)
+	key[8] = step
+	binary.BigEndian.PutUint64(key[9:17], s.seq)
+	return s.db.Put(key, buf.Bytes(), nil)
+}
+
+// get returns and deletes all messages stored for a round and step
+func (s *spillStore) get(round uint64, step uint8) []message.Message {
+	if s == nil {
+		return nil
+	}
+
+	prefix := make([]byte, 9)
+	binary.BigEndian.PutUint64(prefix[0:8], round)
+	prefix[8] = step
+
+	msgs := make([]message.Message, 0)
+	batch := new(leveldb.Batch)
+	iter := s.db.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		batch.Delete(append([]byte{}, iter.Key()...))
+
+		m, err := message.Unmarshal(bytes.NewBuffer(append([]byte{}, iter.Value()...)))
+		if err != nil {
+			log.WithError(err).Warn("could not unmarshal spilled event")
+			continue
+		}
+
+		msgs = append(msgs, m)
+	}
+	iter.Release()
+
+	if err := s.db.Write(batch, nil); err != nil {
+		log.WithError(err).Warn("could not delete spilled events")
+	}
+
+	return msgs
+}
+
+// deleteRound deletes all messages stored for a round
+func (s *spillStore) deleteRound(round uint64) {
+	prefix := make([]byte, 8)
+	binary.BigEndian.PutUint64(prefix, round)
+	s.deleteRange(util.BytesPrefix(prefix))
+}
+
+func (s *spillStore) deleteRange(r *util.Range) {
+	if s == nil {
+		return
+	}
+
+	batch := new(leveldb.Batch)
+	iter := s.db.NewIterator(r, nil)
+	for iter.Next() {
+		batch.Delete(append([]byte{}, iter.Key()...))
+	}
+	iter.Release()
+
+	if err := s.db.Write(batch, nil); err != nil {
+		log.WithError(err).Warn("could not delete spilled events")
+	}
+}
+
+func (s *spillStore) close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,196 @@
+package consensus_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var queueHash = make([]byte, 32)
+
+func mockReduction(k key.Keys, round uint64, step uint8) message.Message {
+	red := message.MockReduction(queueHash, round, step, []key.Keys{k})
+	return message.New(topics.Reduction, red)
+}
+
+func mockKeys(t *testing.T, amount int) []key.Keys {
+	keys := make([]key.Keys, amount)
+	for i := range keys {
+		k, err := key.NewRandKeys()
+		require.NoError(t, err)
+		keys[i] = k
+	}
+
+	return keys
+}
+
+// TestQueueLookAhead tests that messages beyond the look-ahead window are
+// rejected
+func TestQueueLookAhead(t *testing.T) {
+	q := consensus.NewQueueWithLimits(consensus.QueueLimits{MaxRoundsAhead: 2})
+	k := mockKeys(t, 1)[0]
+
+	assert.Empty(t, q.GetEvents(10, 1))
+	assert.NoError(t, q.PutEvent(12, 1, mockReduction(k, 12, 1)))
+	assert.Equal(t, consensus.ErrTooFarAhead, q.PutEvent(13, 1, mockReduction(k, 13, 1)))
+	assert.Equal(t, uint64(1), q.Metrics().RejectedTooFarAhead)
+}
+
+// TestQueueLimits tests the per-round, per-step and per-sender limits
+func TestQueueLimits(t *testing.T) {
+	q := consensus.NewQueueWithLimits(consensus.QueueLimits{
+		MaxPerRound:  4,
+		MaxPerStep:   2,
+		MaxPerSender: 1,
+	})
+	keys := mockKeys(t, 5)
+
+	assert.NoError(t, q.PutEvent(1, 1, mockReduction(keys[0], 1, 1)))
+	assert.Equal(t, consensus.ErrSenderFull, q.PutEvent(1, 2, mockReduction(keys[0], 1, 2)))
+
+	assert.NoError(t, q.PutEvent(1, 1, mockReduction(keys[1], 1, 1)))
+	assert.Equal(t, consensus.ErrStepFull, q.PutEvent(1, 1, mockReduction(keys[2], 1, 1)))
+
+	assert.NoError(t, q.PutEvent(1, 2, mockReduction(keys[2], 1, 2)))
+	assert.NoError(t, q.PutEvent(1, 3, mockReduction(keys[3], 1, 3)))
+	assert.Equal(t, consensus.ErrRoundFull, q.PutEvent(1, 4, mockReduction(keys[4], 1, 4)))
+
+	m := q.Metrics()
+	assert.Equal(t, uint64(4), m.Stored)
+	assert.Equal(t, uint64(1), m.RejectedSenderFull)
+	assert.Equal(t, uint64(1), m.RejectedStepFull)
+	assert.Equal(t, uint64(1), m.RejectedRoundFull)
+
+	assert.Len(t, q.GetEvents(1, 1), 2)
+	assert.Len(t, q.Flush(1), 2)
+	assert.Equal(t, uint64(0), q.Metrics().Stored)
+}
+
+// TestQueueProvisioners tests that the messages of the senders which are not
+// provisioners are rejected before counting against any quota
+func TestQueueProvisioners(t *testing.T) {
+	q := consensus.NewQueueWithLimits(consensus.QueueLimits{MaxPerSender: 1})
+	p, keys := consensus.MockProvisioners(1)
+	outsider := mockKeys(t, 1)[0]
+
+	// any sender is accepted until the provisioners are set
+	assert.NoError(t, q.PutEvent(1, 1, mockReduction(outsider, 1, 1)))
+
+	q.SetProvisioners(*p)
+	assert.Equal(t, consensus.ErrNotProvisioner, q.PutEvent(2, 1, mockReduction(outsider, 2, 1)))
+	assert.NoError(t, q.PutEvent(2, 1, mockReduction(keys[0], 2, 1)))
+
+	m := q.Metrics()
+	assert.Equal(t, uint64(2), m.Stored)
+	assert.Equal(t, uint64(1), m.RejectedNotProvisioner)
+	assert.Equal(t, uint64(0), m.RejectedSenderFull)
+}
+
+// TestQueueEviction tests that obsolete rounds are evicted when the Queue
+// moves forward
+func TestQueueEviction(t *testing.T) {
+	q := consensus.NewQueueWithLimits(consensus.DefaultQueueLimits())
+	keys := mockKeys(t, 3)
+
+	for i, k := range keys {
+		assert.NoError(t, q.PutEvent(uint64(i+1), 1, mockReduction(k, uint64(i+1), 1)))
+	}
+
+	assert.Len(t, q.GetEvents(3, 1), 1)
+	m := q.Metrics()
+	assert.Equal(t, uint64(2), m.Evicted)
+	assert.Equal(t, uint64(0), m.Stored)
+	assert.Empty(t, q.Flush(1))
+}
+
+// TestQueueSpill tests that messages exceeding the memory budget are spilled
+// to disk and retrieved transparently
+func TestQueueSpill(t *testing.T) {
+	dir, err := ioutil.TempDir("", "queue_spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	q := consensus.NewQueueWithLimits(consensus.QueueLimits{
+		MaxInMemory: 1,
+		SpillDir:    dir,
+	})
+	defer func() {
+		_ = q.Close()
+	}()
+
+	keys := mockKeys(t, 3)
+	for _, k := range keys {
+		assert.NoError(t, q.PutEvent(1, 1, mockReduction(k, 1, 1)))
+	}
+
+	assert.Equal(t, uint64(2), q.Metrics().Spilled)
+
+	evs := q.GetEvents(1, 1)
+	require.Len(t, evs, 3)
+	for i, ev := range evs {
+		red := ev.Payload().(message.Reduction)
+		assert.Equal(t, keys[i].BLSPubKeyBytes, red.State().PubKeyBLS)
+	}
+
+	m := q.Metrics()
+	assert.Equal(t, uint64(0), m.Stored)
+	assert.Equal(t, uint64(0), m.Spilled)
+}
+
+// TestQueueFullWithoutSpill tests that messages exceeding the memory budget
+// are rejected if no spill storage is configured
+func TestQueueFullWithoutSpill(t *testing.T) {
+	q := consensus.NewQueueWithLimits(consensus.QueueLimits{MaxInMemory: 1})
+	keys := mockKeys(t, 2)
+
+	assert.NoError(t, q.PutEvent(1, 1, mockReduction(keys[0], 1, 1)))
+	assert.Equal(t, consensus.ErrQueueFull, q.PutEvent(1, 1, mockReduction(keys[1], 1, 1)))
+	assert.Equal(t, uint64(1), q.Metrics().RejectedQueueFull)
+}
+
+// TestSpillingQueues tests that two Queues created out of the same
+// configuration spill into separate storages
+func TestSpillingQueues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "queue_spill")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	prev := cfg.Get()
+	defer cfg.Mock(&prev)
+
+	r := cfg.Get()
+	r.Consensus.Queue.MaxInMemory = 1
+	r.Consensus.Queue.SpillDir = dir
+	cfg.Mock(&r)
+
+	events := consensus.NewSpillingQueue("events")
+	defer func() {
+		_ = events.Close()
+	}()
+
+	rounds := consensus.NewSpillingQueue("rounds")
+	defer func() {
+		_ = rounds.Close()
+	}()
+
+	assert.True(t, events.Metrics().SpillEnabled)
+	assert.True(t, rounds.Metrics().SpillEnabled)
+	assert.False(t, consensus.NewQueue().Metrics().SpillEnabled)
+
+	keys := mockKeys(t, 2)
+	for _, k := range keys {
+		assert.NoError(t, events.PutEvent(1, 1, mockReduction(k, 1, 1)))
+		assert.NoError(t, rounds.PutEvent(1, 1, mockReduction(k, 1, 1)))
+	}
+
+	assert.Len(t, events.GetEvents(1, 1), 2)
+	assert.Len(t, rounds.GetEvents(1, 1), 2)
+}
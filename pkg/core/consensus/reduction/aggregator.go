@@ -68,6 +68,16 @@ func (a *Aggregator) CollectVote(ev message.Reduction) *Result {
 	return nil
 }
 
+// Votes returns the amount of votes collected for a block hash
+func (a *Aggregator) Votes(hash []byte) int {
+	sv, found := a.voteSets[string(hash)]
+	if !found {
+		return 0
+	}
+
+	return sv.Cluster.TotalOccurrences()
+}
+
 func (a *Aggregator) addBitSet(sv *message.StepVotes, cluster sortedset.Cluster, round uint64, step uint8) {
 	committee := a.handler.Committee(round, step)
 	sv.BitSet = committee.Bits(cluster.Set)
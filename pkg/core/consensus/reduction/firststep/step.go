@@ -78,6 +78,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 	}()
 
 	p.handler = reduction.NewHandler(p.Keys, r.P)
+	p.Status.StartStep(r.Round, step, p.String(), p.TimeOut, p.handler.AmMember(r.Round, step))
 
 	// first we send our own Selection
 	if p.handler.AmMember(r.Round, step) {
@@ -161,6 +162,7 @@ func (p *Phase) collectReduction(ctx context.Context, r message.Reduction, round
 	}).Debugln("received_event")
 
 	result := p.aggregator.CollectVote(r)
+	p.Status.SetReductionVotes(hdr.BlockHash, p.aggregator.Votes(hdr.BlockHash))
 	if result == nil {
 		return nil
 	}
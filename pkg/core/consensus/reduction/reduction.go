@@ -67,9 +67,16 @@ func (r *Reduction) SendReduction(round uint64, step uint8, hash []byte) {
 		PubKeyBLS: r.Keys.BLSPubKeyBytes,
 	}
 
+	// A signer may time out or refuse to double-sign. The vote is skipped,
+	// and the step goes on without it
 	sig, err := r.Sign(hdr)
 	if err != nil {
-		panic(err)
+		lg.
+			WithError(err).
+			WithField("round", round).
+			WithField("step", step).
+			Error("could not sign the reduction, skipping the vote")
+		return
 	}
 
 	red := message.NewReduction(hdr)
@@ -106,7 +113,16 @@ func ShouldProcess(m message.Message, round uint64, step uint8, queue *consensus
 				"expected round": round,
 			}).
 			Debugln("storing future event for later")
-		queue.PutEvent(hdr.Round, hdr.Step, m)
+		if err := queue.PutEvent(hdr.Round, hdr.Step, m); err != nil {
+			lg.
+				WithError(err).
+				WithFields(log.Fields{
+					"topic": m.Category(),
+					"round": hdr.Round,
+					"step":  hdr.Step,
+				}).
+				Debugln("discarding future event")
+		}
 		return false
 	}
 
@@ -0,0 +1,40 @@
+package reduction
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// refusingSigner refuses to sign anything
+type refusingSigner struct{}
+
+func (refusingSigner) Sign(signer.Request) ([]byte, error) {
+	return nil, signer.ErrDoubleSign
+}
+
+func (refusingSigner) PublicKey() []byte {
+	return nil
+}
+
+// TestSendReductionSignerError tests that the vote is skipped when the signer
+// fails
+func TestSendReductionSignerError(t *testing.T) {
+	assert := require.New(t)
+	keys, err := key.NewRandKeys()
+	assert.NoError(err)
+
+	eb := eventbus.New()
+	gossipChan := make(chan message.Message, 1)
+	eb.Subscribe(topics.Gossip, eventbus.NewChanListener(gossipChan))
+
+	r := &Reduction{Emitter: &consensus.Emitter{EventBus: eb, Keys: keys, Signer: refusingSigner{}}}
+	assert.NotPanics(func() { r.SendReduction(round, step, EmptyHash[:]) })
+	assert.Empty(gossipChan)
+}
@@ -73,6 +73,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 	}()
 
 	p.handler = reduction.NewHandler(p.Keys, r.P)
+	p.Status.StartStep(r.Round, step, p.String(), p.TimeOut, p.handler.AmMember(r.Round, step))
 	// first we send our own Selection
 	if p.handler.AmMember(r.Round, step) {
 		p.SendReduction(r.Round, step, p.firstStepVotesMsg.BlockHash)
@@ -93,9 +94,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 				continue
 			}
 
-			if stepVotesAreValid(&p.firstStepVotesMsg, svm) && p.handler.AmMember(r.Round, step) {
-				p.sendAgreement(r.Round, step, svm)
-			}
+			p.onQuorum(r.Round, step, svm)
 			return p.next.Initialize(nil)
 		}
 	}
@@ -118,9 +117,7 @@ func (p *Phase) Run(ctx context.Context, queue *consensus.Queue, evChan chan mes
 					<-timeoutChan
 				}()
 
-				if stepVotesAreValid(&p.firstStepVotesMsg, svm) && p.handler.AmMember(r.Round, step) {
-					p.sendAgreement(r.Round, step, svm)
-				}
+				p.onQuorum(r.Round, step, svm)
 				return p.next.Initialize(nil)
 			}
 
@@ -161,6 +158,7 @@ func (p *Phase) collectReduction(r message.Reduction, round uint64, step uint8)
 		//"hash":   hex.EncodeToString(hdr.BlockHash),
 	}).Debugln("received_2nd_step_reduction")
 	result := p.aggregator.CollectVote(r)
+	p.Status.SetReductionVotes(hdr.BlockHash, p.aggregator.Votes(hdr.BlockHash))
 	return p.createStepVoteMessage(result, round, step)
 }
 
@@ -187,6 +185,21 @@ func (p *Phase) createStepVoteMessage(r *reduction.Result, round uint64, step ui
 	}
 }
 
+// onQuorum is called when the second step reached a quorum. If both steps
+// agreed on a block, it is the expected winner of the round: the Agreement is
+// sent (if we are part of the committee) and the candidate gets prepared for
+// acceptance
+func (p *Phase) onQuorum(round uint64, step uint8, svm *message.StepVotesMsg) {
+	if !stepVotesAreValid(&p.firstStepVotesMsg, svm) {
+		return
+	}
+
+	p.PrepareCandidate(svm.BlockHash)
+	if p.handler.AmMember(round, step) {
+		p.sendAgreement(round, step, svm)
+	}
+}
+
 func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMsg) {
 	lg.WithFields(log.Fields{
 		"round": round,
@@ -200,9 +213,14 @@ func (p *Phase) sendAgreement(round uint64, step uint8, svm *message.StepVotesMs
 		BlockHash: svm.BlockHash,
 	}
 
-	sig, err := p.Sign(hdr)
+	sig, err := p.SignAgreement(hdr)
 	if err != nil {
-		panic(err)
+		lg.
+			WithError(err).
+			WithField("round", round).
+			WithField("step", step).
+			Error("could not sign the agreement, skipping it")
+		return
 	}
 
 	lg.WithFields(log.Fields{
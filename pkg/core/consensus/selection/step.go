@@ -125,6 +125,7 @@ func (p *Phase) Run(parentCtx context.Context, queue *consensus.Queue, evChan ch
 	go p.generateCandidate(ctx, r, step, internalScoreChan)
 
 	p.handler = NewScoreHandler(p.provisioner)
+	p.Status.StartStep(r.Round, step, p.String(), p.timeout, false)
 	timeoutChan := time.After(p.timeout)
 	for _, ev := range queue.GetEvents(r.Round, step) {
 		if ev.Category() == topics.Score {
@@ -257,7 +258,16 @@ func shouldProcess(m message.Message, round uint64, step uint8, queue *consensus
 				"expected round": round,
 			}).
 			Debugln("storing future event for later")
-		queue.PutEvent(hdr.Round, hdr.Step, m)
+		if err := queue.PutEvent(hdr.Round, hdr.Step, m); err != nil {
+			lg.
+				WithError(err).
+				WithFields(log.Fields{
+					"topic": m.Category(),
+					"round": hdr.Round,
+					"step":  hdr.Step,
+				}).
+				Debugln("discarding future event")
+		}
 		return false
 	}
 
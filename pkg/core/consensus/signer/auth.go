@@ -0,0 +1,53 @@
+package signer
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tokenHeader is the metadata key carrying the shared token of the signer
+const tokenHeader = "signer-token"
+
+// ErrTokenRequired is returned when the signer is reached over the network
+// without a shared token. Only unix sockets are protected by the file
+// permissions alone
+var ErrTokenRequired = errors.New("a shared token is required to reach the signer over the network")
+
+// RequiresToken tells if the signer calls on the given network need to
+// carry a shared token
+func RequiresToken(network string) bool {
+	return network != "" && network != "unix"
+}
+
+// TokenInterceptor rejects the calls which do not carry the shared token
+// with codes.Unauthenticated
+func TokenInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get(tokenHeader)
+		if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(token)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid signer token")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// tokenCredentials attach the shared token to every call of a Remote
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{tokenHeader: string(t)}, nil
+}
+
+// RequireTransportSecurity is false, as the signer connections are not
+// encrypted
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,31 @@
+package signer
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-crypto/bls"
+)
+
+// Local is a Signer which holds the consensus keys in memory
+type Local struct {
+	keys key.Keys
+}
+
+// NewLocal creates a Local Signer
+func NewLocal(keys key.Keys) *Local {
+	return &Local{keys}
+}
+
+// Sign as defined by the Signer interface
+func (l *Local) Sign(req Request) ([]byte, error) {
+	signed, err := bls.Sign(l.keys.BLSSecretKey, l.keys.BLSPubKey, req.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return signed.Compress(), nil
+}
+
+// PublicKey as defined by the Signer interface
+func (l *Local) PublicKey() []byte {
+	return l.keys.BLSPubKeyBytes
+}
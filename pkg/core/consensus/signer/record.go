@@ -0,0 +1,112 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-crypto/hash"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// Record persists the digest of every payload signed per (domain, round,
+// step), so that a signer never signs two different payloads for the same
+// round and step, even across restarts. Signing the same payload again is
+// allowed, since it yields the very same signature.
+// Keys are composed as domain (1 byte) | round (8 bytes) | step (1 byte)
+type Record struct {
+	lock sync.Mutex
+	db   *leveldb.DB
+}
+
+// OpenRecord opens (or creates) the Record stored in dir
+func OpenRecord(dir string) (*Record, error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{db: db}, nil
+}
+
+// voteSize is the size of a header.MarshalSignableVote preimage: round (8
+// bytes) | step (1 byte) | block hash (32 bytes)
+const voteSize = 41
+
+// maxSeedSize is the size of the largest seed, a compressed BLS signature
+const maxSeedSize = 33
+
+// Check records the Request, unless a different payload was already signed
+// for the same domain, round and step, in which case ErrDoubleSign is
+// returned. The Request is flushed to disk before Check returns.
+// The round and step of the consensus headers are decoded from the payload
+// rather than trusted, and ErrMalformedRequest is returned on mismatch
+func (r *Record) Check(req Request) error {
+	if err := checkPayload(req); err != nil {
+		return err
+	}
+
+	digest, err := hash.Sha3256(req.Payload)
+	if err != nil {
+		return err
+	}
+
+	key := make([]byte, 10)
+	key[0] = byte(req.Domain)
+	binary.BigEndian.PutUint64(key[1:9], req.Round)
+	key[9] = req.Step
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	signed, err := r.db.Get(key, nil)
+	switch err {
+	case nil:
+		if !bytes.Equal(signed, digest) {
+			return ErrDoubleSign
+		}
+		return nil
+	case leveldb.ErrNotFound:
+		return r.db.Put(key, digest, &opt.WriteOptions{Sync: true})
+	default:
+		return err
+	}
+}
+
+// checkPayload makes sure that the payload of a Request fits its Domain. The
+// headers (Vote and Agreement) must commit to the round and step of the
+// Request. A seed does not carry its round, but its signature is the same
+// whatever the round: it only needs not to be mistaken for a header, which is
+// guaranteed by its size
+func checkPayload(req Request) error {
+	switch req.Domain {
+	case Vote, Agreement:
+		if len(req.Payload) != voteSize {
+			return ErrMalformedRequest
+		}
+
+		var h header.Header
+		if err := header.UnmarshalFields(bytes.NewBuffer(req.Payload), &h); err != nil {
+			return ErrMalformedRequest
+		}
+
+		if h.Round != req.Round || h.Step != req.Step {
+			return ErrMalformedRequest
+		}
+	case Seed:
+		if len(req.Payload) == 0 || len(req.Payload) > maxSeedSize {
+			return ErrMalformedRequest
+		}
+	default:
+		return ErrMalformedRequest
+	}
+
+	return nil
+}
+
+// Close the Record
+func (r *Record) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,95 @@
+package signer
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Remote is a Signer delegating the signatures to a signer process (see
+// Service), reachable over a unix socket or TCP
+type Remote struct {
+	conn    *grpc.ClientConn
+	client  SignerClient
+	timeout time.Duration
+	pubKey  []byte
+}
+
+// NewRemote connects to the signer process listening on the given network
+// ("unix" or "tcp") and address, and fetches its public key. The calls carry
+// the shared token, which is mandatory on any network but "unix"
+func NewRemote(network, address, token string, timeout time.Duration) (*Remote, error) {
+	if network == "" {
+		network = "unix"
+	}
+
+	if RequiresToken(network) && token == "" {
+		return nil, ErrTokenRequired
+	}
+
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, network, addr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	opts := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock(), grpc.WithContextDialer(dialer)}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+	}
+
+	conn, err := grpc.DialContext(ctx, address, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Remote{
+		conn:    conn,
+		client:  NewSignerClient(conn),
+		timeout: timeout,
+	}
+
+	resp, err := r.client.PublicKey(ctx, &PublicKeyRequest{})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	r.pubKey = resp.PublicKey
+	return r, nil
+}
+
+// Sign as defined by the Signer interface. It returns ErrDoubleSign if the
+// signer process refused the Request
+func (r *Remote) Sign(req Request) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	resp, err := r.client.Sign(ctx, &req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.FailedPrecondition:
+			return nil, ErrDoubleSign
+		case codes.InvalidArgument:
+			return nil, ErrMalformedRequest
+		}
+		return nil, err
+	}
+
+	return resp.Signature, nil
+}
+
+// PublicKey as defined by the Signer interface
+func (r *Remote) PublicKey() []byte {
+	return r.pubKey
+}
+
+// Close the connection to the signer process
+func (r *Remote) Close() error {
+	return r.conn.Close()
+}
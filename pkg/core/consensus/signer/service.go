@@ -0,0 +1,194 @@
+package signer
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The Signer service is not part of dusk-protobuf. Its messages are encoded
+// in JSON (see rpc.JSONCodecName), and clients need to call it with the
+// rpc.JSONCallOption
+
+const (
+	// SignRoute is the full method name of Signer.Sign
+	SignRoute = "/node.Signer/Sign"
+	// PublicKeyRoute is the full method name of Signer.PublicKey
+	PublicKeyRoute = "/node.Signer/PublicKey"
+)
+
+type (
+	// SignResponse carries the compressed BLS signature of a Request
+	SignResponse struct {
+		Signature []byte `json:"signature"`
+	}
+
+	// PublicKeyRequest is the (empty) request of Signer.PublicKey
+	PublicKeyRequest struct{}
+
+	// PublicKeyResponse carries the marshaled BLS public key of the signer
+	PublicKeyResponse struct {
+		PublicKey []byte `json:"public-key"`
+	}
+
+	// SignerServer is the server API of the Signer service
+	SignerServer interface {
+		Sign(context.Context, *Request) (*SignResponse, error)
+		PublicKey(context.Context, *PublicKeyRequest) (*PublicKeyResponse, error)
+	}
+
+	// SignerClient is the client API of the Signer service
+	SignerClient interface {
+		Sign(context.Context, *Request, ...grpc.CallOption) (*SignResponse, error)
+		PublicKey(context.Context, *PublicKeyRequest, ...grpc.CallOption) (*PublicKeyResponse, error)
+	}
+
+	signerClient struct {
+		cc *grpc.ClientConn
+	}
+)
+
+// Service is the SignerServer run by the signer process. It holds the
+// consensus keys and checks every Request against a persistent Record
+type Service struct {
+	local  *Local
+	record *Record
+}
+
+// NewService creates a Service signing with the given keys
+func NewService(keys key.Keys, record *Record) *Service {
+	return &Service{
+		local:  NewLocal(keys),
+		record: record,
+	}
+}
+
+// Sign as defined by SignerServer. Double-sign attempts are rejected with
+// codes.FailedPrecondition, and the requests not matching their payload with
+// codes.InvalidArgument
+func (s *Service) Sign(ctx context.Context, req *Request) (*SignResponse, error) {
+	if err := s.record.Check(*req); err != nil {
+		lg.
+			WithError(err).
+			WithField("domain", req.Domain).
+			WithField("round", req.Round).
+			WithField("step", req.Step).
+			Warn("sign request rejected")
+		switch err {
+		case ErrDoubleSign:
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case ErrMalformedRequest:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	sig, err := s.local.Sign(*req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignResponse{Signature: sig}, nil
+}
+
+// PublicKey as defined by SignerServer
+func (s *Service) PublicKey(ctx context.Context, req *PublicKeyRequest) (*PublicKeyResponse, error) {
+	return &PublicKeyResponse{PublicKey: s.local.PublicKey()}, nil
+}
+
+// RegisterSignerServer registers the Signer service on a gRPC server
+func RegisterSignerServer(s *grpc.Server, srv SignerServer) {
+	s.RegisterService(&signerServiceDesc, srv)
+}
+
+// NewSignerClient creates a client of the Signer service
+func NewSignerClient(cc *grpc.ClientConn) SignerClient {
+	return &signerClient{cc}
+}
+
+// Sign as defined by SignerClient
+func (c *signerClient) Sign(ctx context.Context, in *Request, opts ...grpc.CallOption) (*SignResponse, error) {
+	out := new(SignResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, SignRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// PublicKey as defined by SignerClient
+func (c *signerClient) PublicKey(ctx context.Context, in *PublicKeyRequest, opts ...grpc.CallOption) (*PublicKeyResponse, error) {
+	out := new(PublicKeyResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, PublicKeyRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func signHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(SignerServer).Sign(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SignRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).Sign(ctx, req.(*Request))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func publicKeyHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(PublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(SignerServer).PublicKey(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PublicKeyRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).PublicKey(ctx, req.(*PublicKeyRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var signerServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Sign",
+			Handler:    signHandler,
+		},
+		{
+			MethodName: "PublicKey",
+			Handler:    publicKeyHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensus/signer/service.go",
+}
@@ -0,0 +1,118 @@
+// Package signer abstracts the BLS signing operations of the consensus, so
+// that the consensus keys can live in a separate process, isolated from the
+// internet-facing node.
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-crypto/bls"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "signer")
+
+var (
+	// ErrDoubleSign is returned when a Signer is asked to sign a payload for
+	// a (round, step) which it already signed a different payload for
+	ErrDoubleSign = errors.New("refusing to double-sign")
+	// ErrMalformedRequest is returned when the payload of a Request does not
+	// fit its Domain, or commits to another round or step
+	ErrMalformedRequest = errors.New("payload does not match the request")
+)
+
+// Domain separates the kinds of payloads which are signed with the consensus
+// keys. Double-signing is checked per Domain
+type Domain uint8
+
+const (
+	// Vote is the domain of the reduction votes
+	Vote Domain = iota
+	// Seed is the domain of the round seed signed by the block generator
+	Seed
+	// Agreement is the domain of the agreements. It is kept apart from Vote,
+	// since an agreement can be for another block than the second reduction
+	// vote of the same round and step
+	Agreement
+)
+
+const (
+	// LocalType is the configuration type of the Local Signer
+	LocalType = "local"
+	// RemoteType is the configuration type of the Remote Signer
+	RemoteType = "remote"
+
+	// DefaultTimeout is the timeout of the calls to a Remote Signer, if not
+	// configured
+	DefaultTimeout = 2 * time.Second
+)
+
+// Request is a request to sign a payload for a round and step
+type Request struct {
+	Domain  Domain `json:"domain"`
+	Round   uint64 `json:"round"`
+	Step    uint8  `json:"step"`
+	Payload []byte `json:"payload"`
+}
+
+// Signer signs consensus payloads with the BLS consensus keys
+type Signer interface {
+	// Sign returns the compressed BLS signature of the Request payload
+	Sign(Request) ([]byte, error)
+	// PublicKey returns the marshaled BLS public key of the Signer
+	PublicKey() []byte
+}
+
+// IsRemote tells if the [consensus.signer] configuration selects the remote
+// Signer. The node then never holds the consensus secret key
+func IsRemote() bool {
+	return cfg.Get().Consensus.Signer.Type == RemoteType
+}
+
+// PublicKeys returns the consensus keys of a Signer, without the secret key
+func PublicKeys(s Signer) (key.Keys, error) {
+	pk, err := bls.UnmarshalPk(s.PublicKey())
+	if err != nil {
+		return key.Keys{}, err
+	}
+
+	return key.Keys{
+		BLSPubKey:      pk,
+		BLSPubKeyBytes: s.PublicKey(),
+	}, nil
+}
+
+// FromConfig creates the Signer selected in the [consensus.signer]
+// configuration. The local Signer uses the keys passed as argument, while the
+// remote Signer is checked to hold the same public key, if any is passed
+func FromConfig(keys key.Keys) (Signer, error) {
+	conf := cfg.Get().Consensus.Signer
+	switch conf.Type {
+	case "", LocalType:
+		return NewLocal(keys), nil
+	case RemoteType:
+		timeout := DefaultTimeout
+		if conf.Timeout > 0 {
+			timeout = time.Duration(conf.Timeout) * time.Millisecond
+		}
+
+		r, err := NewRemote(conf.Network, conf.Address, conf.Token, timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		if keys.BLSPubKeyBytes != nil && !bytes.Equal(r.PublicKey(), keys.BLSPubKeyBytes) {
+			_ = r.Close()
+			return nil, errors.New("the remote signer holds a different consensus key")
+		}
+
+		lg.WithField("address", conf.Address).Info("using remote signer")
+		return r, nil
+	default:
+		return nil, errors.New("unknown signer type " + conf.Type)
+	}
+}
@@ -0,0 +1,166 @@
+package signer_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// vote returns the signable preimage of a vote for a block hash made of b
+func vote(t *testing.T, round uint64, step uint8, b byte) []byte {
+	preimage := new(bytes.Buffer)
+	h := header.Header{Round: round, Step: step, BlockHash: bytes.Repeat([]byte{b}, 32)}
+	require.NoError(t, header.MarshalSignableVote(preimage, h))
+	return preimage.Bytes()
+}
+
+// TestRecordRefusesDoubleSign tests that the Record rejects a different
+// payload for an already signed (round, step), also after being reopened
+func TestRecordRefusesDoubleSign(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer-record")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	record, err := signer.OpenRecord(dir)
+	require.NoError(t, err)
+
+	req := signer.Request{Domain: signer.Vote, Round: 3, Step: 2, Payload: vote(t, 3, 2, 1)}
+	require.NoError(t, record.Check(req))
+	// signing the same payload again is harmless
+	require.NoError(t, record.Check(req))
+
+	// the same block on another step or domain is a different request
+	require.NoError(t, record.Check(signer.Request{Domain: signer.Vote, Round: 3, Step: 3, Payload: vote(t, 3, 3, 1)}))
+	require.NoError(t, record.Check(signer.Request{Domain: signer.Agreement, Round: 3, Step: 2, Payload: vote(t, 3, 2, 2)}))
+	require.NoError(t, record.Check(signer.Request{Domain: signer.Seed, Round: 3, Step: 2, Payload: []byte{2}}))
+
+	require.NoError(t, record.Close())
+	record, err = signer.OpenRecord(dir)
+	require.NoError(t, err)
+	defer record.Close()
+
+	req.Payload = vote(t, 3, 2, 2)
+	assert.Equal(t, signer.ErrDoubleSign, record.Check(req))
+}
+
+// TestRecordRefusesMismatch tests that the Record rejects the requests whose
+// round and step do not match the payload, and the payloads not fitting their
+// domain
+func TestRecordRefusesMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer-record")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	record, err := signer.OpenRecord(dir)
+	require.NoError(t, err)
+	defer record.Close()
+
+	require.NoError(t, record.Check(signer.Request{Domain: signer.Vote, Round: 3, Step: 2, Payload: vote(t, 3, 2, 1)}))
+
+	// a conflicting vote claiming an unused round or step
+	assert.Equal(t, signer.ErrMalformedRequest, record.Check(signer.Request{Domain: signer.Vote, Round: 4, Step: 2, Payload: vote(t, 3, 2, 2)}))
+	assert.Equal(t, signer.ErrMalformedRequest, record.Check(signer.Request{Domain: signer.Vote, Round: 3, Step: 3, Payload: vote(t, 3, 2, 2)}))
+
+	// a vote disguised as a seed, and a truncated vote
+	assert.Equal(t, signer.ErrMalformedRequest, record.Check(signer.Request{Domain: signer.Seed, Round: 3, Step: 2, Payload: vote(t, 3, 2, 2)}))
+	assert.Equal(t, signer.ErrMalformedRequest, record.Check(signer.Request{Domain: signer.Vote, Round: 3, Step: 2, Payload: []byte{1}}))
+}
+
+// TestRemoteSigner tests that the Remote signer yields the same signatures
+// as the Local one, and surfaces the double-sign refusals of the Service
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer-remote")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keys, err := key.NewRandKeys()
+	require.NoError(t, err)
+
+	record, err := signer.OpenRecord(filepath.Join(dir, "record"))
+	require.NoError(t, err)
+	defer record.Close()
+
+	address := filepath.Join(dir, "signer.sock")
+	l, err := net.Listen("unix", address)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	signer.RegisterSignerServer(srv, signer.NewService(keys, record))
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	defer srv.Stop()
+
+	remote, err := signer.NewRemote("unix", address, "", time.Second)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	assert.Equal(t, keys.BLSPubKeyBytes, remote.PublicKey())
+
+	req := signer.Request{Domain: signer.Vote, Round: 1, Step: 1, Payload: vote(t, 1, 1, 1)}
+	sig, err := remote.Sign(req)
+	require.NoError(t, err)
+
+	expected, err := signer.NewLocal(keys).Sign(req)
+	require.NoError(t, err)
+	assert.Equal(t, expected, sig)
+
+	req.Payload = vote(t, 1, 1, 2)
+	_, err = remote.Sign(req)
+	assert.Equal(t, signer.ErrDoubleSign, err)
+
+	req.Round = 2
+	_, err = remote.Sign(req)
+	assert.Equal(t, signer.ErrMalformedRequest, err)
+}
+
+// TestRemoteSignerToken tests that the signer calls over TCP need the shared
+// token
+func TestRemoteSignerToken(t *testing.T) {
+	keys, err := key.NewRandKeys()
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "signer-token")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	record, err := signer.OpenRecord(dir)
+	require.NoError(t, err)
+	defer record.Close()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(signer.TokenInterceptor("secret")))
+	signer.RegisterSignerServer(srv, signer.NewService(keys, record))
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	defer srv.Stop()
+
+	address := l.Addr().String()
+	_, err = signer.NewRemote("tcp", address, "", time.Second)
+	assert.Equal(t, signer.ErrTokenRequired, err)
+
+	_, err = signer.NewRemote("tcp", address, "wrong", time.Second)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	remote, err := signer.NewRemote("tcp", address, "secret", time.Second)
+	require.NoError(t, err)
+	defer remote.Close()
+
+	assert.Equal(t, keys.BLSPubKeyBytes, remote.PublicKey())
+}
@@ -0,0 +1,184 @@
+package stakemanager
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	log "github.com/sirupsen/logrus"
+)
+
+var lg = log.WithField("process", "StakeManager")
+
+// RetryInterval is the amount of blocks after which a failed (or repeatable)
+// Decision is issued again
+const RetryInterval = 20
+
+// Manager watches the stakes of the node on each accepted block and issues
+// the stake, WithdrawStake and WithdrawBid transactions its Policy decides.
+// Unlike the stakeautomaton, it follows the stakes as recorded in the
+// Provisioners, rather than the ones it sent itself
+type Manager struct {
+	eventBroker eventbus.Broker
+	rpcBus      *rpcbus.RPCBus
+	executor    transactions.Executor
+	store       *Store
+	policy      Policy
+
+	pubKeyBLS []byte
+	blockChan <-chan block.Block
+	running   bool
+}
+
+// New creates a Manager. Its decisions are persisted in store
+func New(eventBroker eventbus.Broker, rpcBus *rpcbus.RPCBus, executor transactions.Executor, store *Store, policy Policy) *Manager {
+	return &Manager{
+		eventBroker: eventBroker,
+		rpcBus:      rpcBus,
+		executor:    executor,
+		store:       store,
+		policy:      policy,
+	}
+}
+
+// StoreDir returns the directory of the Store, as configured or next to the
+// chain database
+func StoreDir() string {
+	if dir := config.Get().Consensus.StakeManager.Store; dir != "" {
+		return dir
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "stakemanager")
+}
+
+// Start managing the stakes of the provisioner identified by pubKeyBLS.
+// Calling Start more than once has no effect
+func (m *Manager) Start(pubKeyBLS []byte) {
+	if m.running {
+		return
+	}
+
+	m.pubKeyBLS = pubKeyBLS
+	m.blockChan, _ = consensus.InitAcceptedBlockUpdate(m.eventBroker)
+	m.running = true
+	go m.Listen()
+}
+
+// Listen to the accepted blocks and apply the Policy on each of them
+func (m *Manager) Listen() {
+	for blk := range m.blockChan {
+		if err := m.Manage(blk.Header.Height); err != nil {
+			lg.WithError(err).Error("could not manage the stakes")
+		}
+	}
+}
+
+// Manage applies the Policy to the provisioners state following the block at
+// the given height
+func (m *Manager) Manage(height uint64) error {
+	p, err := m.executor.GetProvisioners(context.Background())
+	if err != nil {
+		return err
+	}
+
+	handled := func(id string) bool {
+		_, found, err := m.store.Get(id)
+		if err != nil {
+			lg.WithError(err).Warn("could not read the stake manager store")
+			// better to skip a renewal than to send it twice
+			return true
+		}
+		return found
+	}
+
+	// the stakes are marked as handled once all the decisions covering them
+	// got issued, so that the failed part of a split renewal is retried
+	decisions := m.policy.Plan(p.GetMember(m.pubKeyBLS), height, handled)
+	pending := make(map[string]bool)
+	for _, d := range decisions {
+		issued, err := m.issue(d, height)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range d.Covers {
+			pending[id] = pending[id] || !issued
+		}
+	}
+
+	for _, d := range decisions {
+		for _, id := range d.Covers {
+			if pending[id] {
+				continue
+			}
+
+			if err := m.store.Put(id, Record{Height: height}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// issue a Decision, unless it was issued already, and tells if it was
+// successfully issued (now or before). The Decision is recorded before the
+// transaction is requested: should the node stop in between, the
+// transaction is not sent rather than risking to send it twice
+func (m *Manager) issue(d Decision, height uint64) (bool, error) {
+	r, found, err := m.store.Get(d.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if found && (r.Err == "" && !d.Repeatable || height < r.Height+RetryInterval) {
+		return r.Err == "", nil
+	}
+
+	if err := m.store.Put(d.ID, Record{Height: height}); err != nil {
+		return false, err
+	}
+
+	l := lg.WithFields(log.Fields{
+		"decision": d.ID,
+		"kind":     d.Kind,
+		"amount":   d.Amount,
+		"height":   height,
+	})
+
+	if err := m.send(d); err != nil {
+		l.WithError(err).Warn("could not issue the stake decision")
+		return false, m.store.Put(d.ID, Record{Height: height, Err: err.Error()})
+	}
+
+	l.Info("stake decision issued")
+	return true, nil
+}
+
+func (m *Manager) send(d Decision) error {
+	timeout := time.Duration(config.Get().Timeout.TimeoutSendStakeTX) * time.Second
+	var err error
+	switch d.Kind {
+	case Stake:
+		req := &node.StakeRequest{
+			Amount:   d.Amount,
+			Fee:      config.MinFee,
+			Locktime: d.LockTime,
+		}
+		_, err = m.rpcBus.Call(topics.SendStakeTx, rpcbus.NewRequest(req), timeout)
+	case WithdrawStake:
+		_, err = m.rpcBus.Call(topics.SendWithdrawStakeTx, rpcbus.NewRequest(d.Withdrawn), timeout)
+	case WithdrawBid:
+		_, err = m.rpcBus.Call(topics.SendWithdrawBidTx, rpcbus.EmptyRequest(), timeout)
+	}
+
+	return err
+}
@@ -0,0 +1,243 @@
+package stakemanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+)
+
+// DefaultRenewalOffset is how many blocks before its EndHeight a stake gets
+// renewed, when the policy does not specify it
+const DefaultRenewalOffset = 100
+
+// Kind of the transaction a Decision issues
+type Kind uint8
+
+const (
+	// Stake sends a new stake
+	Stake Kind = iota
+	// WithdrawStake withdraws an existing stake
+	WithdrawStake
+	// WithdrawBid withdraws the bid of the node
+	WithdrawBid
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Stake:
+		return "stake"
+	case WithdrawStake:
+		return "withdrawstake"
+	case WithdrawBid:
+		return "withdrawbid"
+	default:
+		return "unknown"
+	}
+}
+
+// Decision is a transaction the Policy asks the Manager to issue
+type Decision struct {
+	// ID identifies the decision across restarts
+	ID   string
+	Kind Kind
+	// Amount and LockTime of a new stake
+	Amount   uint64
+	LockTime uint64
+	// Withdrawn is the stake a WithdrawStake decision refers to
+	Withdrawn user.Stake
+	// Covers are the IDs of the stakes handled by this decision. They are
+	// marked once the decision is issued, so that they are not renewed (or
+	// withdrawn) twice
+	Covers []string
+	// Repeatable decisions are issued again if their condition still holds
+	// after RetryInterval blocks, since that proves that the transaction did
+	// not make it into a block. Other decisions are only retried if the call
+	// failed
+	Repeatable bool
+}
+
+// Policy decides how the stakes of the node are managed
+type Policy struct {
+	// RenewalOffset is how many blocks before its EndHeight a stake is renewed
+	RenewalOffset uint64
+	// Amount (in atomic units) staked when the node has no stake at all
+	Amount uint64
+	// LockTime of the new stakes
+	LockTime uint64
+	// MaxStakeAmount (in atomic units) splits the renewed stakes into stakes
+	// of at most this amount. Zero disables the split
+	MaxStakeAmount uint64
+	// Merge renews the stakes expiring together with a single stake
+	Merge bool
+	// Stop withdraws all stakes and the bid, instead of renewing them
+	Stop bool
+}
+
+// PolicyFromConfig creates the Policy from the [consensus.stakemanager]
+// configuration, falling back to the [consensus] default amount and lock time
+func PolicyFromConfig() Policy {
+	consensusCfg := config.Get().Consensus
+	conf := consensusCfg.StakeManager
+
+	p := Policy{
+		RenewalOffset:  conf.RenewalOffset,
+		Amount:         conf.Amount * wallet.DUSK,
+		LockTime:       conf.LockTime,
+		MaxStakeAmount: conf.MaxStakeAmount * wallet.DUSK,
+		Merge:          conf.Merge,
+		Stop:           conf.Stop,
+	}
+
+	if p.RenewalOffset == 0 {
+		p.RenewalOffset = DefaultRenewalOffset
+	}
+
+	if p.Amount == 0 {
+		p.Amount = consensusCfg.DefaultAmount * wallet.DUSK
+	}
+
+	if p.LockTime == 0 {
+		p.LockTime = consensusCfg.DefaultLockTime
+	}
+
+	if p.LockTime > config.MaxLockTime {
+		lg.Warnf("stake locktime exceeds maximum (%v) - defaulting to %v", p.LockTime, config.MaxLockTime)
+		p.LockTime = config.MaxLockTime
+	}
+
+	return p
+}
+
+// stakeID identifies a stake of the node
+func stakeID(s user.Stake) string {
+	return fmt.Sprintf("stake:%d:%d:%d", s.StartHeight, s.EndHeight, s.Amount)
+}
+
+// Plan returns the decisions to take at the given height for the stakes of
+// member, which is nil if the node is not a provisioner. handled tells if a
+// stake was already renewed or withdrawn
+func (p Policy) Plan(member *user.Member, height uint64, handled func(id string) bool) []Decision {
+	var stakes []user.Stake
+	if member != nil {
+		stakes = member.Stakes
+	}
+
+	if p.Stop {
+		return p.planWithdrawal(stakes, handled)
+	}
+
+	if len(stakes) == 0 {
+		if p.Amount == 0 {
+			return nil
+		}
+
+		return []Decision{{
+			ID:         "initial",
+			Kind:       Stake,
+			Amount:     p.Amount,
+			LockTime:   p.LockTime,
+			Repeatable: true,
+		}}
+	}
+
+	expiring := make([]user.Stake, 0, len(stakes))
+	for _, s := range stakes {
+		if height+p.RenewalOffset >= s.EndHeight && !handled(stakeID(s)) {
+			expiring = append(expiring, s)
+		}
+	}
+
+	if len(expiring) == 0 {
+		return nil
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].EndHeight < expiring[j].EndHeight
+	})
+
+	if p.Merge {
+		return p.renew(expiring)
+	}
+
+	decisions := make([]Decision, 0, len(expiring))
+	for _, s := range expiring {
+		decisions = append(decisions, p.renew([]user.Stake{s})...)
+	}
+	return decisions
+}
+
+// renew replaces the given stakes with new stakes for the same total amount,
+// split according to MaxStakeAmount
+func (p Policy) renew(stakes []user.Stake) []Decision {
+	covers := make([]string, len(stakes))
+	var total uint64
+	for i, s := range stakes {
+		covers[i] = stakeID(s)
+		total += s.Amount
+	}
+
+	prefix := "renew:" + strings.Join(covers, "+")
+	chunks := split(total, p.MaxStakeAmount)
+	decisions := make([]Decision, len(chunks))
+	for i, amount := range chunks {
+		decisions[i] = Decision{
+			ID:       fmt.Sprintf("%s:%d", prefix, i),
+			Kind:     Stake,
+			Amount:   amount,
+			LockTime: p.LockTime,
+			Covers:   covers,
+		}
+	}
+
+	return decisions
+}
+
+func (p Policy) planWithdrawal(stakes []user.Stake, handled func(id string) bool) []Decision {
+	decisions := make([]Decision, 0, len(stakes)+1)
+	for _, s := range stakes {
+		id := stakeID(s)
+		if handled(id) {
+			continue
+		}
+
+		decisions = append(decisions, Decision{
+			ID:        "withdraw:" + id,
+			Kind:      WithdrawStake,
+			Withdrawn: s,
+			Covers:    []string{id},
+		})
+	}
+
+	if !handled("bid") {
+		decisions = append(decisions, Decision{
+			ID:     "withdraw:bid",
+			Kind:   WithdrawBid,
+			Covers: []string{"bid"},
+		})
+	}
+
+	return decisions
+}
+
+// split an amount into chunks of at most max
+func split(amount, max uint64) []uint64 {
+	if max == 0 || amount <= max {
+		return []uint64{amount}
+	}
+
+	chunks := make([]uint64, 0, amount/max+1)
+	for amount > max {
+		chunks = append(chunks, max)
+		amount -= max
+	}
+
+	if amount > 0 {
+		chunks = append(chunks, amount)
+	}
+
+	return chunks
+}
@@ -0,0 +1,168 @@
+package stakemanager_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/stakemanager"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/dusk-network/dusk-protobuf/autogen/go/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pubKeyBLS = make([]byte, 129)
+
+func nothingHandled(string) bool { return false }
+
+// TestPlanInitialStake tests that a node without stakes stakes the policy
+// amount
+func TestPlanInitialStake(t *testing.T) {
+	p := stakemanager.Policy{RenewalOffset: 10, Amount: 500, LockTime: 1000}
+	d := p.Plan(nil, 5, nothingHandled)
+	require.Len(t, d, 1)
+	assert.Equal(t, stakemanager.Stake, d[0].Kind)
+	assert.Equal(t, uint64(500), d[0].Amount)
+	assert.True(t, d[0].Repeatable)
+}
+
+// TestPlanRenewal tests that only the stakes within the renewal offset are
+// renewed, split and merged as the policy says
+func TestPlanRenewal(t *testing.T) {
+	m := &user.Member{Stakes: []user.Stake{
+		{Amount: 300, StartHeight: 0, EndHeight: 100},
+		{Amount: 200, StartHeight: 10, EndHeight: 105},
+		{Amount: 100, StartHeight: 20, EndHeight: 500},
+	}}
+
+	p := stakemanager.Policy{RenewalOffset: 10, LockTime: 1000}
+	assert.Empty(t, p.Plan(m, 80, nothingHandled))
+
+	d := p.Plan(m, 95, nothingHandled)
+	require.Len(t, d, 2)
+	assert.Equal(t, uint64(300), d[0].Amount)
+	assert.Equal(t, uint64(200), d[1].Amount)
+
+	p.Merge = true
+	d = p.Plan(m, 95, nothingHandled)
+	require.Len(t, d, 1)
+	assert.Equal(t, uint64(500), d[0].Amount)
+	assert.Len(t, d[0].Covers, 2)
+
+	p.MaxStakeAmount = 200
+	d = p.Plan(m, 95, nothingHandled)
+	require.Len(t, d, 3)
+	assert.Equal(t, uint64(200), d[0].Amount)
+	assert.Equal(t, uint64(200), d[1].Amount)
+	assert.Equal(t, uint64(100), d[2].Amount)
+
+	// handled stakes are not renewed again
+	assert.Empty(t, p.Plan(m, 95, func(string) bool { return true }))
+}
+
+// TestPlanStop tests that stopping withdraws every stake and the bid
+func TestPlanStop(t *testing.T) {
+	m := &user.Member{Stakes: []user.Stake{
+		{Amount: 300, StartHeight: 0, EndHeight: 100},
+		{Amount: 100, StartHeight: 20, EndHeight: 500},
+	}}
+
+	p := stakemanager.Policy{RenewalOffset: 10, Stop: true}
+	d := p.Plan(m, 50, nothingHandled)
+	require.Len(t, d, 3)
+	assert.Equal(t, stakemanager.WithdrawStake, d[0].Kind)
+	assert.Equal(t, m.Stakes[0], d[0].Withdrawn)
+	assert.Equal(t, stakemanager.WithdrawStake, d[1].Kind)
+	assert.Equal(t, stakemanager.WithdrawBid, d[2].Kind)
+}
+
+type provisionersExecutor struct {
+	transactions.PermissiveExecutor
+	p user.Provisioners
+}
+
+func (e *provisionersExecutor) GetProvisioners(ctx context.Context) (user.Provisioners, error) {
+	return e.p, nil
+}
+
+// catchRequests answers the requests on a topic with the given error, and
+// counts them
+func catchRequests(t *testing.T, rb *rpcbus.RPCBus, topic topics.Topic, err error) *int32 {
+	c := make(chan rpcbus.Request, 1)
+	require.NoError(t, rb.Register(topic, c))
+
+	count := new(int32)
+	go func() {
+		for r := range c {
+			atomic.AddInt32(count, 1)
+			r.RespChan <- rpcbus.NewResponse(&node.TransactionResponse{}, err)
+		}
+	}()
+
+	return count
+}
+
+// TestManagerPersistsDecisions tests that a renewal is sent only once, even
+// across restarts of the Manager
+func TestManagerPersistsDecisions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stakemanager")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	p := user.NewProvisioners()
+	require.NoError(t, p.Add(pubKeyBLS, 1000, 0, 100))
+	e := &provisionersExecutor{p: *p}
+
+	rb := rpcbus.New()
+	stakes := catchRequests(t, rb, topics.SendStakeTx, nil)
+	policy := stakemanager.Policy{RenewalOffset: 10, LockTime: 1000}
+
+	store, err := stakemanager.OpenStore(dir)
+	require.NoError(t, err)
+	m := stakemanager.New(eventbus.New(), rb, e, store, policy)
+	require.NoError(t, m.Manage(91))
+	require.NoError(t, m.Manage(92))
+	assert.Equal(t, int32(1), atomic.LoadInt32(stakes))
+	require.NoError(t, store.Close())
+
+	store, err = stakemanager.OpenStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+	m = stakemanager.New(eventbus.New(), rb, e, store, policy)
+	require.NoError(t, m.Manage(93))
+	assert.Equal(t, int32(1), atomic.LoadInt32(stakes))
+}
+
+// TestManagerRetriesFailures tests that failed decisions are issued again
+// after RetryInterval blocks
+func TestManagerRetriesFailures(t *testing.T) {
+	p := user.NewProvisioners()
+	require.NoError(t, p.Add(pubKeyBLS, 1000, 0, 100))
+	e := &provisionersExecutor{p: *p}
+
+	rb := rpcbus.New()
+	withdrawals := catchRequests(t, rb, topics.SendWithdrawStakeTx, errors.New("mempool full"))
+	bids := catchRequests(t, rb, topics.SendWithdrawBidTx, nil)
+
+	store, err := stakemanager.OpenStore("")
+	require.NoError(t, err)
+	defer store.Close()
+
+	m := stakemanager.New(eventbus.New(), rb, e, store, stakemanager.Policy{Stop: true})
+	require.NoError(t, m.Manage(10))
+	require.NoError(t, m.Manage(11))
+	assert.Equal(t, int32(1), atomic.LoadInt32(withdrawals))
+	assert.Equal(t, int32(1), atomic.LoadInt32(bids))
+
+	require.NoError(t, m.Manage(10+stakemanager.RetryInterval))
+	assert.Equal(t, int32(2), atomic.LoadInt32(withdrawals))
+	assert.Equal(t, int32(1), atomic.LoadInt32(bids))
+}
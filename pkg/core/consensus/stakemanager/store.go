@@ -0,0 +1,81 @@
+package stakemanager
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// Record is the outcome of an issued Decision
+type Record struct {
+	// Height at which the Decision was issued
+	Height uint64 `json:"height"`
+	// Err of the call issuing the Decision, if it failed
+	Err string `json:"error,omitempty"`
+}
+
+// Store persists the issued decisions, so that a restart of the node does not
+// send the same transaction twice. Decisions are recorded (and synced to
+// disk) before their transaction is requested
+type Store struct {
+	lock sync.Mutex
+	db   *leveldb.DB
+}
+
+// OpenStore opens (or creates) the Store in dir. An empty dir creates an
+// in-memory Store
+func OpenStore(dir string) (*Store, error) {
+	var db *leveldb.DB
+	var err error
+	if dir == "" {
+		db, err = leveldb.Open(storage.NewMemStorage(), nil)
+	} else {
+		db, err = leveldb.OpenFile(dir, nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Get the Record of a decision or of a handled stake
+func (s *Store) Get(id string) (Record, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, err := s.db.Get([]byte(id), nil)
+	switch err {
+	case nil:
+		var r Record
+		if err := json.Unmarshal(v, &r); err != nil {
+			return Record{}, false, err
+		}
+		return r, true, nil
+	case leveldb.ErrNotFound:
+		return Record{}, false, nil
+	default:
+		return Record{}, false, err
+	}
+}
+
+// Put a Record
+func (s *Store) Put(id string, r Record) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.db.Put([]byte(id), v, &opt.WriteOptions{Sync: true})
+}
+
+// Close the Store
+func (s *Store) Close() error {
+	return s.db.Close()
+}
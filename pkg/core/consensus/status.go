@@ -0,0 +1,169 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+type (
+	// Status is a read-only snapshot of what the consensus loop is doing
+	Status struct {
+		Round uint64 `json:"round"`
+		Step  uint8  `json:"step"`
+		// Phase is the name of the running phase, as returned by
+		// PhaseFn.String()
+		Phase   string        `json:"phase"`
+		Timeout time.Duration `json:"timeout"`
+		// CommitteeMember tells if this node is part of the committee for
+		// the current step
+		CommitteeMember bool `json:"committee-member"`
+		// ReductionVotes is the amount of votes collected per block hash (hex
+		// encoded) by the reduction aggregator of the current step
+		ReductionVotes map[string]int `json:"reduction-votes"`
+		// Agreement is the quorum progress of the agreement loop
+		Agreement AgreementProgress `json:"agreement"`
+		// LastCertificate is the certificate of the last agreed block
+		LastCertificate *block.Certificate `json:"last-certificate"`
+	}
+
+	// AgreementProgress carries the amount of agreement votes collected per
+	// step and the quorum they need to reach
+	AgreementProgress struct {
+		Quorum int           `json:"quorum"`
+		Votes  map[uint8]int `json:"votes"`
+	}
+
+	// StatusTracker records the Status of the consensus. It is thread-safe
+	// and all methods can be safely called on a nil StatusTracker, in which
+	// case nothing gets recorded
+	StatusTracker struct {
+		lock   sync.RWMutex
+		status Status
+	}
+)
+
+// NewStatusTracker creates an empty StatusTracker
+func NewStatusTracker() *StatusTracker {
+	return &StatusTracker{
+		status: Status{
+			ReductionVotes: make(map[string]int),
+			Agreement:      AgreementProgress{Votes: make(map[uint8]int)},
+		},
+	}
+}
+
+// StartRound resets the Status at the beginning of a new round
+func (t *StatusTracker) StartRound(round uint64, lastCertificate *block.Certificate) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status = Status{
+		Round:           round,
+		ReductionVotes:  make(map[string]int),
+		Agreement:       AgreementProgress{Votes: make(map[uint8]int)},
+		LastCertificate: lastCertificate,
+	}
+}
+
+// StartStep records the beginning of a step of the current round
+func (t *StatusTracker) StartStep(round uint64, step uint8, phase string, timeout time.Duration, committeeMember bool) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.Round = round
+	t.status.Step = step
+	t.status.Phase = phase
+	t.status.Timeout = timeout
+	t.status.CommitteeMember = committeeMember
+	t.status.ReductionVotes = make(map[string]int)
+}
+
+// SetReductionVotes records the amount of votes collected for a block hash
+// during the current reduction step
+func (t *StatusTracker) SetReductionVotes(hash []byte, votes int) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.ReductionVotes[hex.EncodeToString(hash)] = votes
+}
+
+// SetAgreementVotes records the amount of agreement votes collected for a step
+// of the current round
+func (t *StatusTracker) SetAgreementVotes(step uint8, votes, quorum int) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.Agreement.Quorum = quorum
+	t.status.Agreement.Votes[step] = votes
+}
+
+// SetLastCertificate records the certificate produced by the agreement
+func (t *StatusTracker) SetLastCertificate(cert *block.Certificate) {
+	if t == nil {
+		return
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.status.LastCertificate = cert
+}
+
+// Snapshot returns a deep copy of the current Status
+func (t *StatusTracker) Snapshot() Status {
+	if t == nil {
+		return Status{}
+	}
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	s := t.status
+	s.ReductionVotes = make(map[string]int, len(t.status.ReductionVotes))
+	for hash, votes := range t.status.ReductionVotes {
+		s.ReductionVotes[hash] = votes
+	}
+
+	s.Agreement.Votes = make(map[uint8]int, len(t.status.Agreement.Votes))
+	for step, votes := range t.status.Agreement.Votes {
+		s.Agreement.Votes[step] = votes
+	}
+
+	if t.status.LastCertificate != nil {
+		s.LastCertificate = t.status.LastCertificate.Copy()
+	}
+
+	return s
+}
+
+// Listen serves the topics.GetConsensusStatus requests on the RPCBus with the
+// Status snapshot
+func (t *StatusTracker) Listen(rpcBus *rpcbus.RPCBus) error {
+	reqChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetConsensusStatus, reqChan); err != nil {
+		return err
+	}
+
+	go func() {
+		for r := range reqChan {
+			r.RespChan <- rpcbus.NewResponse(t.Snapshot(), nil)
+		}
+	}()
+
+	return nil
+}
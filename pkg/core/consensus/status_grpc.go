@@ -0,0 +1,97 @@
+package consensus
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc"
+	"google.golang.org/grpc"
+)
+
+// The ConsensusStatus service is not part of dusk-protobuf. Its messages are
+// encoded in JSON (see rpc.JSONCodecName), and clients need to call it with
+// the rpc.JSONCallOption
+
+// GetStatusRoute is the full method name of ConsensusStatus.GetStatus
+const GetStatusRoute = "/node.ConsensusStatus/GetStatus"
+
+type (
+	// StatusRequest is the (empty) request of ConsensusStatus.GetStatus
+	StatusRequest struct{}
+
+	// StatusServer is the server API of the ConsensusStatus service
+	StatusServer interface {
+		GetStatus(context.Context, *StatusRequest) (*Status, error)
+	}
+
+	// StatusClient is the client API of the ConsensusStatus service
+	StatusClient interface {
+		GetStatus(context.Context, *StatusRequest, ...grpc.CallOption) (*Status, error)
+	}
+
+	statusClient struct {
+		cc *grpc.ClientConn
+	}
+)
+
+// GetStatus returns the current Status. It complies with the StatusServer
+// interface
+func (t *StatusTracker) GetStatus(ctx context.Context, req *StatusRequest) (*Status, error) {
+	s := t.Snapshot()
+	return &s, nil
+}
+
+// RegisterStatusServer registers the ConsensusStatus service on a gRPC server
+func RegisterStatusServer(s *grpc.Server, srv StatusServer) {
+	s.RegisterService(&statusServiceDesc, srv)
+}
+
+// NewStatusClient creates a client of the ConsensusStatus service
+func NewStatusClient(cc *grpc.ClientConn) StatusClient {
+	return &statusClient{cc}
+}
+
+// GetStatus as defined by StatusClient
+func (c *statusClient) GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*Status, error) {
+	out := new(Status)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, GetStatusRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func getStatusHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(StatusServer).GetStatus(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GetStatusRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StatusServer).GetStatus(ctx, req.(*StatusRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var statusServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.ConsensusStatus",
+	HandlerType: (*StatusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetStatus",
+			Handler:    getStatusHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensus/status_grpc.go",
+}
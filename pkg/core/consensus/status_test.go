@@ -0,0 +1,73 @@
+package consensus_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatusTracker tests that the StatusTracker records the consensus
+// progress and resets it on each round and step
+func TestStatusTracker(t *testing.T) {
+	tracker := consensus.NewStatusTracker()
+	cert := block.EmptyCertificate()
+
+	tracker.StartRound(2, cert)
+	tracker.StartStep(2, 1, "selection", time.Second, false)
+	tracker.StartStep(2, 2, "reduction-first-step", 2*time.Second, true)
+	tracker.SetReductionVotes([]byte{0xaa}, 3)
+	tracker.SetAgreementVotes(2, 10, 42)
+
+	s := tracker.Snapshot()
+	assert.Equal(t, uint64(2), s.Round)
+	assert.Equal(t, uint8(2), s.Step)
+	assert.Equal(t, "reduction-first-step", s.Phase)
+	assert.Equal(t, 2*time.Second, s.Timeout)
+	assert.True(t, s.CommitteeMember)
+	assert.Equal(t, 3, s.ReductionVotes["aa"])
+	assert.Equal(t, 42, s.Agreement.Quorum)
+	assert.Equal(t, 10, s.Agreement.Votes[2])
+	assert.Equal(t, cert, s.LastCertificate)
+
+	// a new step resets the reduction votes only
+	tracker.StartStep(2, 3, "reduction-second-step", time.Second, false)
+	s = tracker.Snapshot()
+	assert.Empty(t, s.ReductionVotes)
+	assert.Equal(t, 10, s.Agreement.Votes[2])
+
+	// a new round resets everything
+	tracker.StartRound(3, nil)
+	s = tracker.Snapshot()
+	assert.Equal(t, uint64(3), s.Round)
+	assert.Empty(t, s.Agreement.Votes)
+	assert.Nil(t, s.LastCertificate)
+}
+
+// TestStatusOverRPCBus tests that the Status can be requested through the
+// RPCBus
+func TestStatusOverRPCBus(t *testing.T) {
+	rb := rpcbus.New()
+	tracker := consensus.NewStatusTracker()
+	require.NoError(t, tracker.Listen(rb))
+
+	tracker.StartRound(5, nil)
+	resp, err := rb.Call(topics.GetConsensusStatus, rpcbus.EmptyRequest(), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), resp.(consensus.Status).Round)
+}
+
+// TestNilStatusTracker tests that a nil StatusTracker can be safely used
+func TestNilStatusTracker(t *testing.T) {
+	var tracker *consensus.StatusTracker
+	tracker.StartRound(1, nil)
+	tracker.StartStep(1, 1, "selection", time.Second, false)
+	tracker.SetReductionVotes([]byte{0xaa}, 1)
+	tracker.SetAgreementVotes(1, 1, 1)
+	assert.Equal(t, consensus.Status{}, tracker.Snapshot())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/sortedset"
+	"github.com/dusk-network/dusk-crypto/hash"
 )
 
 type (
@@ -192,6 +193,25 @@ func (p *Provisioners) TotalWeight() (totalWeight uint64) {
 	return totalWeight
 }
 
+// Hash returns the SHA3-256 digest of the members and stakes of the
+// Provisioners, walked in the order of the Set. It identifies a provisioner
+// set regardless of the Members map ordering
+func (p Provisioners) Hash() ([]byte, error) {
+	r := new(bytes.Buffer)
+	for _, pk := range p.Set {
+		member := p.GetMember(pk.Bytes())
+		if member == nil {
+			continue
+		}
+
+		if err := marshalMember(r, *member); err != nil {
+			return nil, err
+		}
+	}
+
+	return hash.Sha3256(r.Bytes())
+}
+
 // MarshalProvisioners ...
 func MarshalProvisioners(r *bytes.Buffer, p *Provisioners) error {
 	if err := encoding.WriteVarInt(r, uint64(len(p.Members))); err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/capi"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/firststep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/reduction/secondstep"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/selection"
@@ -90,8 +91,8 @@ func New(e *consensus.Emitter) *Consensus {
 
 	c := &Consensus{
 		Emitter:       e,
-		eventQueue:    consensus.NewQueue(),
-		roundQueue:    consensus.NewQueue(),
+		eventQueue:    consensus.NewSpillingQueue("events"),
+		roundQueue:    consensus.NewSpillingQueue("rounds"),
 		agreementChan: agreementChan,
 		eventChan:     eventChan,
 	}
@@ -119,6 +120,11 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 	// We create a channel on which to communicate round results, so that they
 	// can be returned to the caller on a successful completion.
 	roundResultsChan := make(chan roundResults, 1)
+	c.Status.StartRound(round.Round, round.LastCertificate)
+
+	// the future messages are only queued if they come from a provisioner
+	c.eventQueue.SetProvisioners(round.P)
+	c.roundQueue.SetProvisioners(round.P)
 
 	// the agreement loop needs to be running until either the consensus
 	// reaches a maximum amount of iterations (approx. 213 steps), or we get
@@ -166,7 +172,7 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 			Trace("new phase")
 
 		if config.Get().API.Enabled {
-			go report(round.Round, step)
+			go c.report(round.Round, step, phaseFunction.String())
 		}
 
 		if step >= 213 {
@@ -191,22 +197,53 @@ func (c *Consensus) Spin(ctx context.Context, scr consensus.Phase, ag consensus.
 	// loop
 }
 
-var steps = []string{"selection", "reduction1", "reduction2"} // nolint
+// report records the beginning of a step and the content of the event queues
+// on the consensus API db
+func (c *Consensus) report(round uint64, step uint8, name string) {
+	store := capi.GetStormDBInstance()
+	l := lg.WithFields(log.Fields{
+		"round": round,
+		"step":  step,
+	})
+
+	if err := store.StoreRoundInfo(round, step, "Forward", name); err != nil {
+		l.WithError(err).Error("could not save StoreRoundInfo on api db")
+	}
 
-func report(round uint64, step uint8) {
-	/*
-		store := capi.GetBuntStoreInstance()
-		err := store.StoreRoundInfo(round, step, "Forward", steps[(step-1)%3])
-		if err != nil {
-			lg.
-				WithFields(log.Fields{
-					"round": round,
-					"step":  step,
-				}).
-				WithError(err).
-				Error("could not save StoreRoundInfo on api db")
+	queues := map[string]*consensus.Queue{"events": c.eventQueue, "rounds": c.roundQueue}
+	for name, queue := range queues {
+		for _, q := range queue.Snapshot() {
+			if err := store.StoreEventQueue(round, step, q.Round, q.Step, q.Count); err != nil {
+				l.WithError(err).Error("could not save StoreEventQueue on api db")
+			}
 		}
-	*/
+
+		m := queue.Metrics()
+		metrics := capi.QueueMetricsJSON{
+			Name:                   name,
+			Stored:                 m.Stored,
+			Spilled:                m.Spilled,
+			SpillEnabled:           m.SpillEnabled,
+			RejectedTooFarAhead:    m.RejectedTooFarAhead,
+			RejectedRoundFull:      m.RejectedRoundFull,
+			RejectedStepFull:       m.RejectedStepFull,
+			RejectedSenderFull:     m.RejectedSenderFull,
+			RejectedNotProvisioner: m.RejectedNotProvisioner,
+			RejectedQueueFull:      m.RejectedQueueFull,
+			Evicted:                m.Evicted,
+		}
+
+		if err := store.StoreQueueMetrics(metrics); err != nil {
+			l.WithError(err).Error("could not save StoreQueueMetrics on api db")
+		}
+	}
+
+	// old snapshots are dropped once per round
+	if step == 1 {
+		if err := store.PruneExpired(); err != nil {
+			l.WithError(err).Warn("could not prune the api db")
+		}
+	}
 }
 
 //phase should start by
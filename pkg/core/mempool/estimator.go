@@ -0,0 +1,129 @@
+package mempool
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/blockgenerator/candidate"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// EstimatorWindow is the amount of recent accepted blocks the fee estimation
+// is based on. It is also the highest target accepted by EstimateFee
+const EstimatorWindow = 20
+
+// ErrInvalidTarget is returned when a fee is estimated for a target out of
+// [1, EstimatorWindow]
+var ErrInvalidTarget = errors.New("target blocks must be between 1 and 20")
+
+// FeeEstimate is the fee a tx should pay to be included within TargetBlocks
+// blocks
+type FeeEstimate struct {
+	TargetBlocks uint32 `json:"target_blocks"`
+	Fee          uint64 `json:"fee"`
+}
+
+// feeEstimator tracks the lowest fee included in the recent accepted blocks
+type feeEstimator struct {
+	lock sync.RWMutex
+	// minFees holds the lowest fee included in each of the last
+	// EstimatorWindow blocks, oldest first. Zero means that the block had
+	// room for any tx
+	minFees []uint64
+}
+
+func (e *feeEstimator) onBlock(b block.Block) {
+	var minFee uint64
+	found := false
+	for _, tx := range b.Txs {
+		if tx.Type() == transactions.Distribute {
+			continue
+		}
+
+		if _, fee := tx.Values(); !found || fee < minFee {
+			minFee = fee
+			found = true
+		}
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.minFees = append(e.minFees, minFee)
+	if len(e.minFees) > EstimatorWindow {
+		e.minFees = e.minFees[1:]
+	}
+}
+
+// historicFee returns a fee which would have been included in the recent
+// blocks. A target of 1 block returns the highest of their lowest fees, while
+// higher targets settle for lower ones
+func (e *feeEstimator) historicFee(target uint32) uint64 {
+	e.lock.RLock()
+	fees := make([]uint64, len(e.minFees))
+	copy(fees, e.minFees)
+	e.lock.RUnlock()
+
+	if len(fees) == 0 {
+		return 0
+	}
+
+	sort.Slice(fees, func(i, j int) bool { return fees[i] > fees[j] })
+	idx := (len(fees) - 1) * int(target-1) / int(target)
+	return fees[idx]
+}
+
+// pendingFee returns the fee needed to get ahead of the pooled txs which do
+// not fit in the next target blocks. It is zero if all of them fit
+func (m *Mempool) pendingFee(target uint32) uint64 {
+	capacity := uint64(target) * candidate.MaxTxSetSize
+
+	var size, fee uint64
+	_ = m.verified.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		size += uint64(t.size)
+		if size <= capacity {
+			return false, nil
+		}
+
+		_, fee = t.tx.Values()
+		fee++
+		return true, nil
+	})
+
+	return fee
+}
+
+// estimateFee returns the fee a tx should pay to be included within target
+// blocks, based on the fees included in the recent blocks and on the fees of
+// the txs currently waiting in the mempool. It is never lower than
+// config.MinFee
+func (m *Mempool) estimateFee(target uint32) (FeeEstimate, error) {
+	if target == 0 || target > EstimatorWindow {
+		return FeeEstimate{}, ErrInvalidTarget
+	}
+
+	fee := config.MinFee
+	if historic := m.estimator.historicFee(target); historic > fee {
+		fee = historic
+	}
+
+	if pending := m.pendingFee(target); pending > fee {
+		fee = pending
+	}
+
+	return FeeEstimate{TargetBlocks: target, Fee: fee}, nil
+}
+
+// processEstimateFeeRequest answers a topics.EstimateFee request. Its params
+// are the uint32 target blocks
+func (m *Mempool) processEstimateFeeRequest(r rpcbus.Request) (interface{}, error) {
+	target, ok := r.Params.(uint32)
+	if !ok {
+		return nil, errors.New("target blocks must be an uint32")
+	}
+
+	return m.estimateFee(target)
+}
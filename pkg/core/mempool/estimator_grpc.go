@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc"
+	"google.golang.org/grpc"
+)
+
+// The FeeEstimator service is not part of dusk-protobuf. Its messages are
+// encoded in JSON (see rpc.JSONCodecName), and clients need to call it with
+// the rpc.JSONCallOption
+
+// EstimateFeeRoute is the full method name of FeeEstimator.EstimateFee
+const EstimateFeeRoute = "/node.FeeEstimator/EstimateFee"
+
+type (
+	// EstimateFeeRequest is the request of FeeEstimator.EstimateFee
+	EstimateFeeRequest struct {
+		TargetBlocks uint32 `json:"target_blocks"`
+	}
+
+	// FeeEstimatorServer is the server API of the FeeEstimator service
+	FeeEstimatorServer interface {
+		EstimateFee(context.Context, *EstimateFeeRequest) (*FeeEstimate, error)
+	}
+
+	// FeeEstimatorClient is the client API of the FeeEstimator service
+	FeeEstimatorClient interface {
+		EstimateFee(context.Context, *EstimateFeeRequest, ...grpc.CallOption) (*FeeEstimate, error)
+	}
+
+	feeEstimatorClient struct {
+		cc *grpc.ClientConn
+	}
+)
+
+// EstimateFee returns the fee a tx should pay to be included within the
+// requested target blocks. It complies with the FeeEstimatorServer interface
+func (m *Mempool) EstimateFee(ctx context.Context, req *EstimateFeeRequest) (*FeeEstimate, error) {
+	e, err := m.estimateFee(req.TargetBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
+// RegisterFeeEstimatorServer registers the FeeEstimator service on a gRPC
+// server
+func RegisterFeeEstimatorServer(s *grpc.Server, srv FeeEstimatorServer) {
+	s.RegisterService(&feeEstimatorServiceDesc, srv)
+}
+
+// NewFeeEstimatorClient creates a client of the FeeEstimator service
+func NewFeeEstimatorClient(cc *grpc.ClientConn) FeeEstimatorClient {
+	return &feeEstimatorClient{cc}
+}
+
+// EstimateFee as defined by FeeEstimatorClient
+func (c *feeEstimatorClient) EstimateFee(ctx context.Context, in *EstimateFeeRequest, opts ...grpc.CallOption) (*FeeEstimate, error) {
+	out := new(FeeEstimate)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, EstimateFeeRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func estimateFeeHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(EstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(FeeEstimatorServer).EstimateFee(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EstimateFeeRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeeEstimatorServer).EstimateFee(ctx, req.(*EstimateFeeRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var feeEstimatorServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.FeeEstimator",
+	HandlerType: (*FeeEstimatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EstimateFee",
+			Handler:    estimateFeeHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mempool/estimator_grpc.go",
+}
@@ -0,0 +1,79 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	assert "github.com/stretchr/testify/require"
+)
+
+func blockWithFees(fees ...uint64) block.Block {
+	blk := block.NewBlock()
+	for _, fee := range fees {
+		blk.Txs = append(blk.Txs, newFeeTx(fee))
+	}
+	return *blk
+}
+
+// TestHistoricFee tests that higher targets settle for lower fees among the
+// lowest fees of the recent blocks
+func TestHistoricFee(t *testing.T) {
+	assert := assert.New(t)
+	e := &feeEstimator{}
+	assert.Equal(uint64(0), e.historicFee(1))
+
+	for _, fee := range []uint64{500, 300, 100} {
+		e.onBlock(blockWithFees(fee, fee+1000))
+	}
+
+	assert.Equal(uint64(500), e.historicFee(1))
+	assert.Equal(uint64(300), e.historicFee(2))
+	assert.Equal(uint64(100), e.historicFee(EstimatorWindow))
+
+	for i := 0; i < EstimatorWindow; i++ {
+		e.onBlock(blockWithFees())
+	}
+	assert.Len(e.minFees, EstimatorWindow)
+	assert.Equal(uint64(0), e.historicFee(1))
+}
+
+// TestEstimateFee tests that the estimation outbids the pooled txs which do
+// not fit in the target blocks, and never goes below the minimum fee
+func TestEstimateFee(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+	m.estimator = &feeEstimator{}
+
+	_, err := m.estimateFee(0)
+	assert.Equal(ErrInvalidTarget, err)
+
+	e, err := m.estimateFee(1)
+	assert.NoError(err)
+	assert.Equal(config.MinFee, e.Fee)
+
+	// each tx takes most of the block
+	size := uint(100 * 1000)
+	for _, fee := range []uint64{1000, 2000, 3000} {
+		_, err := m.processTx(TxDesc{tx: newFeeTx(fee), received: time.Now(), size: size})
+		assert.NoError(err)
+	}
+
+	e, err = m.estimateFee(1)
+	assert.NoError(err)
+	assert.Equal(uint64(2001), e.Fee)
+
+	// all of them fit in two blocks
+	e, err = m.estimateFee(2)
+	assert.NoError(err)
+	assert.Equal(config.MinFee, e.Fee)
+
+	// the recent blocks raise the estimation when nothing is pending
+	m.verified = m.newPool()
+	m.estimator.onBlock(blockWithFees(700))
+	m.estimator.onBlock(blockWithFees(5000))
+	e, err = m.estimateFee(1)
+	assert.NoError(err)
+	assert.Equal(uint64(5000), e.Fee)
+}
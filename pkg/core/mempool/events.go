@@ -0,0 +1,87 @@
+package mempool
+
+import (
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+)
+
+// TxEventType tells what happened to a tx in the mempool
+type TxEventType string
+
+const (
+	// Added txs were verified and put into the pool
+	Added TxEventType = "added"
+	// Included txs left the pool as they were accepted in a block
+	Included TxEventType = "included"
+	// Evicted txs left the pool without being accepted in a block. The
+	// EvictionReason is the event Reason
+	Evicted TxEventType = "evicted"
+	// Rejected txs never entered the pool. The rejection error is the event
+	// Reason
+	Rejected TxEventType = "rejected"
+)
+
+// TxEvent is published on topics.MempoolEvent whenever a tx enters or leaves
+// the mempool, or is rejected by it
+type TxEvent struct {
+	Type   TxEventType         `json:"type"`
+	TxID   []byte              `json:"txid"`
+	TxType transactions.TxType `json:"tx_type"`
+	Fee    uint64              `json:"fee"`
+	Size   uint                `json:"size"`
+	Reason string              `json:"reason,omitempty"`
+}
+
+// Copy complies with the payload.Safe interface
+func (e TxEvent) Copy() payload.Safe {
+	c := e
+	c.TxID = make([]byte, len(e.TxID))
+	copy(c.TxID, e.TxID)
+	return c
+}
+
+// TxEventFilter selects the TxEvents of some TxTypes. An empty filter selects
+// all of them
+type TxEventFilter []transactions.TxType
+
+// Match tells if the filter selects a TxEvent
+func (f TxEventFilter) Match(e TxEvent) bool {
+	if len(f) == 0 {
+		return true
+	}
+
+	for _, t := range f {
+		if t == e.TxType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newTxEvent(eventType TxEventType, t TxDesc, txid []byte, reason string) TxEvent {
+	_, fee := t.tx.Values()
+	return TxEvent{
+		Type:   eventType,
+		TxID:   txid,
+		TxType: t.tx.Type(),
+		Fee:    fee,
+		Size:   t.size,
+		Reason: reason,
+	}
+}
+
+// publishTxEvent notifies the subscribers of topics.MempoolEvent
+func (m *Mempool) publishTxEvent(e TxEvent) {
+	msg := message.New(topics.MempoolEvent, e)
+	errList := m.eventBus.Publish(topics.MempoolEvent, msg)
+	diagnostics.LogPublishErrors("mempool.go, topics.MempoolEvent", errList)
+}
+
+// notifyRejected publishes the rejection of a tx
+func (m *Mempool) notifyRejected(t TxDesc, txid []byte, err error) {
+	m.publishTxEvent(newTxEvent(Rejected, t, txid, err.Error()))
+}
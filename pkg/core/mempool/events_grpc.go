@@ -0,0 +1,161 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"google.golang.org/grpc"
+)
+
+// The MempoolEvents service is not part of dusk-protobuf. Its messages are
+// encoded in JSON (see rpc.JSONCodecName), and clients need to call it with
+// the rpc.JSONCallOption
+
+// SubscribeTxEventsRoute is the full method name of
+// MempoolEvents.SubscribeTxEvents
+const SubscribeTxEventsRoute = "/node.MempoolEvents/SubscribeTxEvents"
+
+// txEventsBuffer is the amount of TxEvents buffered for each subscriber.
+// Events are dropped for the subscribers which do not keep up
+const txEventsBuffer = 1000
+
+type (
+	// SubscribeTxEventsRequest is the request of
+	// MempoolEvents.SubscribeTxEvents. No TxTypes streams the events of all
+	// of them
+	SubscribeTxEventsRequest struct {
+		TxTypes []transactions.TxType `json:"tx_types"`
+	}
+
+	// MempoolEventsServer is the server API of the MempoolEvents service
+	MempoolEventsServer interface {
+		SubscribeTxEvents(*SubscribeTxEventsRequest, MempoolEvents_SubscribeTxEventsServer) error
+	}
+
+	// MempoolEvents_SubscribeTxEventsServer is the server side stream of
+	// MempoolEvents.SubscribeTxEvents
+	MempoolEvents_SubscribeTxEventsServer interface { //nolint
+		Send(*TxEvent) error
+		grpc.ServerStream
+	}
+
+	// MempoolEventsClient is the client API of the MempoolEvents service
+	MempoolEventsClient interface {
+		SubscribeTxEvents(context.Context, *SubscribeTxEventsRequest, ...grpc.CallOption) (MempoolEvents_SubscribeTxEventsClient, error)
+	}
+
+	// MempoolEvents_SubscribeTxEventsClient is the client side stream of
+	// MempoolEvents.SubscribeTxEvents
+	MempoolEvents_SubscribeTxEventsClient interface { //nolint
+		Recv() (*TxEvent, error)
+		grpc.ClientStream
+	}
+
+	mempoolEventsClient struct {
+		cc *grpc.ClientConn
+	}
+
+	subscribeTxEventsServer struct {
+		grpc.ServerStream
+	}
+
+	subscribeTxEventsClient struct {
+		grpc.ClientStream
+	}
+)
+
+// SubscribeTxEvents streams the TxEvents of the requested TxTypes until the
+// client goes away. It complies with the MempoolEventsServer interface
+func (m *Mempool) SubscribeTxEvents(req *SubscribeTxEventsRequest, stream MempoolEvents_SubscribeTxEventsServer) error {
+	filter := TxEventFilter(req.TxTypes)
+	events := make(chan message.Message, txEventsBuffer)
+	id := m.eventBus.Subscribe(topics.MempoolEvent, eventbus.NewSafeChanListener(events))
+	defer m.eventBus.Unsubscribe(topics.MempoolEvent, id)
+
+	for {
+		select {
+		case msg := <-events:
+			e := msg.Payload().(TxEvent)
+			if !filter.Match(e) {
+				continue
+			}
+
+			if err := stream.Send(&e); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+// RegisterMempoolEventsServer registers the MempoolEvents service on a gRPC
+// server
+func RegisterMempoolEventsServer(s *grpc.Server, srv MempoolEventsServer) {
+	s.RegisterService(&mempoolEventsServiceDesc, srv)
+}
+
+// NewMempoolEventsClient creates a client of the MempoolEvents service
+func NewMempoolEventsClient(cc *grpc.ClientConn) MempoolEventsClient {
+	return &mempoolEventsClient{cc}
+}
+
+// SubscribeTxEvents as defined by MempoolEventsClient
+func (c *mempoolEventsClient) SubscribeTxEvents(ctx context.Context, in *SubscribeTxEventsRequest, opts ...grpc.CallOption) (MempoolEvents_SubscribeTxEventsClient, error) {
+	opts = append(opts, rpc.JSONCallOption())
+	stream, err := c.cc.NewStream(ctx, &mempoolEventsServiceDesc.Streams[0], SubscribeTxEventsRoute, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &subscribeTxEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+func (x *subscribeTxEventsClient) Recv() (*TxEvent, error) {
+	m := new(TxEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (x *subscribeTxEventsServer) Send(m *TxEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func subscribeTxEventsHandler(srv interface{}, stream grpc.ServerStream) error {
+	in := new(SubscribeTxEventsRequest)
+	if err := stream.RecvMsg(in); err != nil {
+		return err
+	}
+
+	return srv.(MempoolEventsServer).SubscribeTxEvents(in, &subscribeTxEventsServer{stream})
+}
+
+var mempoolEventsServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.MempoolEvents",
+	HandlerType: (*MempoolEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeTxEvents",
+			Handler:       subscribeTxEventsHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mempool/events_grpc.go",
+}
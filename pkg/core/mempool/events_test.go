@@ -0,0 +1,68 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestTxEvents tests that txs entering, leaving or being rejected by the
+// mempool are published on topics.MempoolEvent
+func TestTxEvents(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+
+	events := make(chan message.Message, 10)
+	m.eventBus.Subscribe(topics.MempoolEvent, eventbus.NewChanListener(events))
+	next := func() TxEvent {
+		select {
+		case msg := <-events:
+			return msg.Payload().(TxEvent)
+		case <-time.After(time.Second):
+			assert.FailNow("no event published")
+			return TxEvent{}
+		}
+	}
+
+	tx := newFeeTx(42)
+	txid, err := m.processTx(TxDesc{tx: tx, received: time.Now(), size: 100})
+	assert.NoError(err)
+
+	e := next()
+	assert.Equal(Added, e.Type)
+	assert.Equal(txid, e.TxID)
+	assert.Equal(tx.Type(), e.TxType)
+	assert.Equal(uint64(42), e.Fee)
+	assert.Equal(uint(100), e.Size)
+
+	_, err = m.processTx(TxDesc{tx: tx, received: time.Now(), size: 100})
+	assert.True(errors.Is(err, ErrAlreadyExists))
+
+	e = next()
+	assert.Equal(Rejected, e.Type)
+	assert.Equal(ErrAlreadyExists.Error(), e.Reason)
+
+	var k txHash
+	copy(k[:], txid)
+	m.evict(k, Expired)
+
+	e = next()
+	assert.Equal(Evicted, e.Type)
+	assert.Equal(string(Expired), e.Reason)
+	assert.Equal(uint(100), e.Size)
+}
+
+func TestTxEventFilter(t *testing.T) {
+	assert := assert.New(t)
+	e := TxEvent{TxType: transactions.Stake}
+
+	assert.True(TxEventFilter{}.Match(e))
+	assert.True(TxEventFilter{transactions.Bid, transactions.Stake}.Match(e))
+	assert.False(TxEventFilter{transactions.Tx}.Match(e))
+}
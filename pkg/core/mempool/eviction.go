@@ -0,0 +1,138 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/diagnostics"
+)
+
+// DefaultTxTTL is how long a tx is kept in the mempool when no TxTTL is
+// configured
+const DefaultTxTTL = time.Hour
+
+// ErrMempoolFull is returned when the mempool is over its byte budget and
+// a tx does not pay more than the lowest fee in the pool
+var ErrMempoolFull = errors.New("mempool is full")
+
+// EvictionReason tells why a tx was evicted from the mempool
+type EvictionReason string
+
+const (
+	// Expired txs were not included in a block within the TTL
+	Expired EvictionReason = "expired"
+	// LowFee txs were evicted to make room for higher fee ones
+	LowFee EvictionReason = "low-fee"
+	// Replaced txs were replaced by a higher fee tx spending the same
+	// nullifiers
+	Replaced EvictionReason = "replaced"
+	// DoubleSpent txs spend nullifiers already spent in an accepted block
+	DoubleSpent EvictionReason = "double-spent"
+)
+
+// EvictedTx is published on topics.EvictedTx when a tx leaves the mempool
+// without being accepted in a block
+type EvictedTx struct {
+	TxID   []byte
+	Reason EvictionReason
+}
+
+// Copy complies with the payload.Safe interface
+func (e EvictedTx) Copy() payload.Safe {
+	txid := make([]byte, len(e.TxID))
+	copy(txid, e.TxID)
+	return EvictedTx{TxID: txid, Reason: e.Reason}
+}
+
+func maxSizeBytes() uint32 {
+	return config.Get().Mempool.MaxSizeMB * 1000 * 1000
+}
+
+func txTTL() time.Duration {
+	if ttl := config.Get().Mempool.TxTTL; ttl > 0 {
+		return time.Duration(ttl) * time.Second
+	}
+
+	return DefaultTxTTL
+}
+
+// checkCapacity rejects a tx which does not fit in the byte budget, unless
+// it pays a higher fee than the lowest one in the pool
+func (m *Mempool) checkCapacity(t TxDesc) error {
+	if m.verified.Size()+uint32(t.size) <= maxSizeBytes() {
+		return nil
+	}
+
+	_, lowest, ok := m.verified.LowestFee()
+	if !ok {
+		// a single tx bigger than the whole budget
+		return fmt.Errorf("%w: tx size %d exceeds the pool budget", ErrMempoolFull, t.size)
+	}
+
+	_, fee := t.tx.Values()
+	_, minFee := lowest.tx.Values()
+	if fee <= minFee {
+		return fmt.Errorf("%w: fee %d is not above the pool minimum %d", ErrMempoolFull, fee, minFee)
+	}
+
+	return nil
+}
+
+// evictOverBudget evicts the lowest fee txs until the pool fits in its byte
+// budget
+func (m *Mempool) evictOverBudget() {
+	maxSize := maxSizeBytes()
+	for m.verified.Size() > maxSize {
+		k, _, ok := m.verified.LowestFee()
+		if !ok {
+			return
+		}
+
+		m.evict(k, LowFee)
+	}
+}
+
+// expireTxs evicts the txs received longer than the TTL ago
+func (m *Mempool) expireTxs(now time.Time) {
+	ttl := txTTL()
+	expired := make([]txHash, 0)
+	_ = m.verified.Range(func(k txHash, t TxDesc) error {
+		if now.Sub(t.received) > ttl {
+			expired = append(expired, k)
+		}
+		return nil
+	})
+
+	for _, k := range expired {
+		m.evict(k, Expired)
+	}
+}
+
+// evict a tx from the verified pool and notify the subscribers (most notably
+// the wallet) about it
+func (m *Mempool) evict(k txHash, reason EvictionReason) {
+	t, ok := m.verified.GetDesc(k[:])
+	if !ok || !m.verified.Delete(k[:]) {
+		return
+	}
+
+	m.notifyEvicted(k, t, reason)
+}
+
+// notifyEvicted logs and publishes the eviction of a tx
+func (m *Mempool) notifyEvicted(k txHash, t TxDesc, reason EvictionReason) {
+	log.WithField("txid", toHex(k[:])).
+		WithField("reason", reason).
+		Info("evicted transaction")
+
+	msg := message.New(topics.EvictedTx, EvictedTx{TxID: k[:], Reason: reason})
+	errList := m.eventBus.Publish(topics.EvictedTx, msg)
+	diagnostics.LogPublishErrors("mempool.go, topics.EvictedTx", errList)
+
+	m.publishTxEvent(newTxEvent(Evicted, t, k[:], string(reason)))
+}
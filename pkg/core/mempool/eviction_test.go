@@ -0,0 +1,132 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+// feeTx is a transaction paying a given fee
+type feeTx struct {
+	*transactions.Transaction
+	fee uint64
+}
+
+func (f feeTx) Values() (uint64, uint64) {
+	return 0, f.fee
+}
+
+func newFeeTx(fee uint64) feeTx {
+	tx := transactions.RandTx()
+	spendRandom(tx)
+	return feeTx{Transaction: tx, fee: fee}
+}
+
+// newEvictionMempool returns a Mempool which is not running, along with the
+// channel where its evictions are published
+func newEvictionMempool() (*Mempool, chan EvictedTx) {
+	bus := eventbus.New()
+	evicted := make(chan EvictedTx, 10)
+	bus.Subscribe(topics.EvictedTx, eventbus.NewCallbackListener(func(m message.Message) {
+		evicted <- m.Payload().(EvictedTx)
+	}))
+
+	v := &transactions.MockProxy{}
+	m := &Mempool{
+		ctx:      context.Background(),
+		eventBus: bus,
+		verifier: v.Prober(),
+		quitChan: make(chan struct{}),
+	}
+	m.verified = m.newPool()
+	return m, evicted
+}
+
+// TestLowestFeeEviction tests that a full mempool evicts its lowest fee txs
+// for higher fee ones, and rejects the ones not paying more
+func TestLowestFeeEviction(t *testing.T) {
+	assert := assert.New(t)
+	m, evicted := newEvictionMempool()
+
+	// the pool budget is 1MB (see TestMain)
+	size := uint(400 * 1000)
+	low, high := newFeeTx(10), newFeeTx(30)
+	for _, tx := range []feeTx{low, high} {
+		_, err := m.processTx(TxDesc{tx: tx, received: time.Now(), size: size})
+		assert.NoError(err)
+	}
+
+	_, err := m.processTx(TxDesc{tx: newFeeTx(10), received: time.Now(), size: size})
+	assert.True(errors.Is(err, ErrMempoolFull))
+
+	mid := newFeeTx(20)
+	_, err = m.processTx(TxDesc{tx: mid, received: time.Now(), size: size})
+	assert.NoError(err)
+
+	lowID, _ := low.CalculateHash()
+	assert.False(m.verified.Contains(lowID))
+	assert.Equal(2, m.verified.Len())
+	assert.Equal(uint32(2*size), m.verified.Size())
+
+	e := <-evicted
+	assert.Equal(lowID, e.TxID)
+	assert.Equal(LowFee, e.Reason)
+}
+
+// TestExpiredEviction tests that the txs older than the TTL are evicted
+func TestExpiredEviction(t *testing.T) {
+	assert := assert.New(t)
+	m, evicted := newEvictionMempool()
+
+	old, fresh := newFeeTx(10), newFeeTx(10)
+	_, err := m.processTx(TxDesc{tx: old, received: time.Now().Add(-2 * DefaultTxTTL), size: 100})
+	assert.NoError(err)
+	_, err = m.processTx(TxDesc{tx: fresh, received: time.Now(), size: 100})
+	assert.NoError(err)
+
+	m.expireTxs(time.Now())
+	assert.Equal(1, m.verified.Len())
+
+	oldID, _ := old.CalculateHash()
+	e := <-evicted
+	assert.Equal(oldID, e.TxID)
+	assert.Equal(Expired, e.Reason)
+}
+
+// TestHashMapDelete tests that deleted entries leave both the map and the
+// sorted keys
+func TestHashMapDelete(t *testing.T) {
+	assert := assert.New(t)
+	pool := &HashMap{lock: &sync.RWMutex{}, Capacity: 10}
+
+	txs := []feeTx{newFeeTx(5), newFeeTx(5), newFeeTx(1), newFeeTx(9)}
+	for _, tx := range txs {
+		assert.NoError(pool.Put(TxDesc{tx: tx, size: 10}))
+	}
+
+	id, _ := txs[1].CalculateHash()
+	assert.True(pool.Delete(id))
+	assert.False(pool.Delete(id))
+	assert.Equal(3, pool.Len())
+	assert.Equal(uint32(30), pool.Size())
+
+	k, _, ok := pool.LowestFee()
+	assert.True(ok)
+	lowest, _ := txs[2].CalculateHash()
+	assert.Equal(lowest, k[:])
+
+	count := 0
+	assert.NoError(pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+		count++
+		return false, nil
+	}))
+	assert.Equal(3, count)
+}
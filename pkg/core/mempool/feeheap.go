@@ -0,0 +1,313 @@
+package mempool
+
+import (
+	"container/heap"
+	"sync"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+)
+
+type (
+	// feeEntry is the position of a tx in the fee ordering. Entries sharing
+	// a fee are ordered by seq, that is by order of insertion
+	feeEntry struct {
+		k     txHash
+		fee   uint64
+		seq   uint64
+		index int
+	}
+
+	// minFeeHeap keeps the lowest fee (and latest received) entry on top
+	minFeeHeap []*feeEntry
+
+	// maxFeeHeap keeps the highest fee (and earliest received) entry on top.
+	// It is built on a snapshot of the entries to iterate them sorted
+	maxFeeHeap []feeEntry
+
+	// FeeHeap represents a pool implementation based on a fee-ordered heap.
+	// Unlike the HashMap, insertions and deletions are O(log n), at the price
+	// of ordering the entries when iterating them sorted by Fee.
+	//
+	// The txs and the nullifier index are guarded by the same lock, so that
+	// SpentBy never returns the key of a tx which Get does not find. Range and
+	// RangeSort iterate over a snapshot taken under the read lock, so that
+	// the pool can be read and written while iterating
+	FeeHeap struct {
+		lock    sync.RWMutex
+		data    map[txHash]TxDesc
+		entries map[txHash]*feeEntry
+		fees    minFeeHeap
+		seq     uint64
+		txsSize uint32
+
+		// spent indexes the nullifiers of the pooled txs by the key of the
+		// tx spending them
+		spent map[string]txHash
+	}
+)
+
+func (h minFeeHeap) Len() int { return len(h) }
+
+func (h minFeeHeap) Less(i, j int) bool {
+	if h[i].fee == h[j].fee {
+		return h[i].seq > h[j].seq
+	}
+	return h[i].fee < h[j].fee
+}
+
+func (h minFeeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *minFeeHeap) Push(x interface{}) {
+	e := x.(*feeEntry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *minFeeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+func (h maxFeeHeap) Len() int { return len(h) }
+
+func (h maxFeeHeap) Less(i, j int) bool {
+	if h[i].fee == h[j].fee {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].fee > h[j].fee
+}
+
+func (h maxFeeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *maxFeeHeap) Push(x interface{}) {
+	*h = append(*h, x.(feeEntry))
+}
+
+func (h *maxFeeHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	*h = old[:n-1]
+	return e
+}
+
+// NewFeeHeap returns an empty FeeHeap with room for capacity txs
+func NewFeeHeap(capacity uint32) *FeeHeap {
+	return &FeeHeap{
+		data:    make(map[txHash]TxDesc, capacity),
+		entries: make(map[txHash]*feeEntry, capacity),
+		fees:    make(minFeeHeap, 0, capacity),
+		spent:   make(map[string]txHash, capacity),
+	}
+}
+
+// Put sets the value for the given key. It overwrites any previous value
+// for that key;
+func (p *FeeHeap) Put(t TxDesc) error {
+	txID, err := t.tx.CalculateHash()
+	if err != nil {
+		return err
+	}
+
+	var k txHash
+	copy(k[:], txID)
+	_, fee := t.tx.Values()
+
+	p.lock.Lock()
+	if old, ok := p.data[k]; ok {
+		p.txsSize -= uint32(old.size)
+		heap.Remove(&p.fees, p.entries[k].index)
+	}
+
+	p.data[k] = t
+	p.txsSize += uint32(t.size)
+
+	p.seq++
+	e := &feeEntry{k: k, fee: fee, seq: p.seq}
+	p.entries[k] = e
+	heap.Push(&p.fees, e)
+
+	for _, n := range t.tx.StandardTx().Nullifiers {
+		p.spent[string(n.Data)] = k
+	}
+	p.lock.Unlock()
+
+	return nil
+}
+
+// Get retrieves a transaction for a given txID, if it exists.
+func (p *FeeHeap) Get(txID []byte) transactions.ContractCall {
+	var k txHash
+	copy(k[:], txID)
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	t, ok := p.data[k]
+	if !ok {
+		return nil
+	}
+	return t.tx
+}
+
+// GetDesc retrieves the TxDesc of a given txID, if it exists.
+func (p *FeeHeap) GetDesc(txID []byte) (TxDesc, bool) {
+	var k txHash
+	copy(k[:], txID)
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	t, ok := p.data[k]
+	return t, ok
+}
+
+// Contains returns true if the given key is in the pool.
+func (p *FeeHeap) Contains(txID []byte) bool {
+	var k txHash
+	copy(k[:], txID)
+
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, ok := p.data[k]
+	return ok
+}
+
+// Delete removes a tx for a given txID if it exists.
+func (p *FeeHeap) Delete(txID []byte) bool {
+	var k txHash
+	copy(k[:], txID)
+
+	p.lock.Lock()
+	t, ok := p.data[k]
+	if !ok {
+		p.lock.Unlock()
+		return false
+	}
+
+	delete(p.data, k)
+	p.txsSize -= uint32(t.size)
+	heap.Remove(&p.fees, p.entries[k].index)
+	delete(p.entries, k)
+
+	for _, n := range t.tx.StandardTx().Nullifiers {
+		if p.spent[string(n.Data)] == k {
+			delete(p.spent, string(n.Data))
+		}
+	}
+	p.lock.Unlock()
+
+	return true
+}
+
+// SpentBy returns the key of the tx spending a nullifier, if any
+func (p *FeeHeap) SpentBy(nullifier []byte) (txHash, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	k, ok := p.spent[string(nullifier)]
+	return k, ok
+}
+
+// Clone the entire pool
+func (p *FeeHeap) Clone() []transactions.ContractCall {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	r := make([]transactions.ContractCall, 0, len(p.data))
+	for _, t := range p.data {
+		r = append(r, t.tx)
+	}
+
+	return r
+}
+
+// FilterByType returns all transactions for a specific type that are
+// currently in the FeeHeap.
+func (p *FeeHeap) FilterByType(filterType transactions.TxType) []transactions.ContractCall {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	txs := make([]transactions.ContractCall, 0)
+	for _, t := range p.data {
+		if t.tx.Type() == filterType {
+			txs = append(txs, t.tx)
+		}
+	}
+
+	return txs
+}
+
+// Size of the txs
+func (p *FeeHeap) Size() uint32 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.txsSize
+}
+
+// Len returns the number of tx entries
+func (p *FeeHeap) Len() int {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return len(p.data)
+}
+
+// Range iterates through a snapshot of all tx entries
+func (p *FeeHeap) Range(fn func(k txHash, t TxDesc) error) error {
+	p.lock.RLock()
+	snapshot := make(map[txHash]TxDesc, len(p.data))
+	for k, t := range p.data {
+		snapshot[k] = t
+	}
+	p.lock.RUnlock()
+
+	for k, t := range snapshot {
+		if err := fn(k, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RangeSort iterates through a snapshot of all tx entries sorted by Fee in a
+// descending order. Entries are popped from a max heap as the iteration
+// goes, so that stopping early does not pay for sorting the whole pool
+func (p *FeeHeap) RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error {
+	p.lock.RLock()
+	fees := make(maxFeeHeap, len(p.fees))
+	data := make(map[txHash]TxDesc, len(p.data))
+	for i, e := range p.fees {
+		fees[i] = *e
+		data[e.k] = p.data[e.k]
+	}
+	p.lock.RUnlock()
+
+	heap.Init(&fees)
+	for fees.Len() > 0 {
+		e := heap.Pop(&fees).(feeEntry)
+		done, err := fn(e.k, data[e.k])
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
+
+// LowestFee returns the entry on top of the heap
+func (p *FeeHeap) LowestFee() (txHash, TxDesc, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if len(p.fees) == 0 {
+		return txHash{}, TxDesc{}, false
+	}
+
+	k := p.fees[0].k
+	return k, p.data[k], true
+}
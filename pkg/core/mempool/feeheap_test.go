@@ -0,0 +1,202 @@
+package mempool
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+const benchPoolTxs = 100000
+
+// pools are the Pool implementations to test and bench against each other
+var pools = map[string]func(capacity uint32) Pool{
+	"hashmap": func(capacity uint32) Pool {
+		return &HashMap{lock: &sync.RWMutex{}, Capacity: capacity}
+	},
+	"heap": func(capacity uint32) Pool {
+		return NewFeeHeap(capacity)
+	},
+}
+
+func randFeeTxs(amount int, maxFee int64) []TxDesc {
+	txs := make([]TxDesc, amount)
+	for i := range txs {
+		fee, err := rand.Int(rand.Reader, big.NewInt(maxFee))
+		if err != nil {
+			panic(err)
+		}
+
+		txs[i] = TxDesc{tx: newFeeTx(fee.Uint64()), received: time.Now(), size: 100}
+	}
+
+	return txs
+}
+
+// TestPoolsOrder tests that all the pools iterate their txs by descending
+// fee, keeping the order of insertion among the same fee txs, and agree on
+// the lowest fee one
+func TestPoolsOrder(t *testing.T) {
+	txs := randFeeTxs(300, 10)
+	for name, newPool := range pools {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			pool := newPool(10)
+			for _, td := range txs {
+				assert.NoError(pool.Put(td))
+			}
+
+			position := make(map[txHash]int, len(txs))
+			for i, td := range txs {
+				id, _ := td.tx.CalculateHash()
+				var k txHash
+				copy(k[:], id)
+				position[k] = i
+			}
+
+			count := 0
+			prevFee, prevPos := uint64(10), -1
+			assert.NoError(pool.RangeSort(func(k txHash, td TxDesc) (bool, error) {
+				_, fee := td.tx.Values()
+				assert.True(fee <= prevFee)
+				if fee == prevFee {
+					assert.True(position[k] > prevPos)
+				}
+
+				prevFee, prevPos = fee, position[k]
+				count++
+				return false, nil
+			}))
+			assert.Equal(len(txs), count)
+
+			// the lowest fee entry is the last one iterated
+			k, _, ok := pool.LowestFee()
+			assert.True(ok)
+			assert.Equal(prevPos, position[k])
+		})
+	}
+}
+
+// TestFeeHeapDelete tests that deleted entries leave the heap and the
+// nullifier index
+func TestFeeHeapDelete(t *testing.T) {
+	assert := assert.New(t)
+	pool := NewFeeHeap(10)
+
+	txs := []feeTx{newFeeTx(5), newFeeTx(5), newFeeTx(1), newFeeTx(9)}
+	for _, tx := range txs {
+		assert.NoError(pool.Put(TxDesc{tx: tx, size: 10}))
+	}
+
+	id, _ := txs[2].CalculateHash()
+	nullifier := txs[2].TxPayload.Nullifiers[0].Data
+	_, ok := pool.SpentBy(nullifier)
+	assert.True(ok)
+
+	assert.True(pool.Delete(id))
+	assert.False(pool.Delete(id))
+	assert.Equal(3, pool.Len())
+	assert.Equal(uint32(30), pool.Size())
+
+	_, ok = pool.SpentBy(nullifier)
+	assert.False(ok)
+
+	k, _, ok := pool.LowestFee()
+	assert.True(ok)
+	lowest, _ := txs[1].CalculateHash()
+	assert.Equal(lowest, k[:])
+}
+
+// TestFeeHeapRangeSnapshot tests that the pool can be written while it is
+// being iterated
+func TestFeeHeapRangeSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	pool := NewFeeHeap(10)
+	for _, td := range randFeeTxs(10, 100) {
+		assert.NoError(pool.Put(td))
+	}
+
+	assert.NoError(pool.RangeSort(func(k txHash, td TxDesc) (bool, error) {
+		assert.True(pool.Delete(k[:]))
+		return false, nil
+	}))
+	assert.Equal(0, pool.Len())
+}
+
+func benchPools(b *testing.B, fn func(b *testing.B, newPool func(uint32) Pool, txs []TxDesc)) {
+	txs := randFeeTxs(benchPoolTxs, 1000000)
+	for _, name := range []string{"hashmap", "heap"} {
+		newPool := pools[name]
+		b.Run(name, func(b *testing.B) {
+			fn(b, newPool, txs)
+		})
+	}
+}
+
+func fillPool(b *testing.B, newPool func(uint32) Pool, txs []TxDesc) Pool {
+	pool := newPool(uint32(len(txs)))
+	for _, td := range txs {
+		if err := pool.Put(td); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return pool
+}
+
+// BenchmarkPoolPut measures filling a pool with 100k txs
+func BenchmarkPoolPut(b *testing.B) {
+	benchPools(b, func(b *testing.B, newPool func(uint32) Pool, txs []TxDesc) {
+		for n := 0; n < b.N; n++ {
+			_ = fillPool(b, newPool, txs)
+		}
+	})
+}
+
+// BenchmarkPoolRangeSort measures iterating a pool of 100k txs by fee
+func BenchmarkPoolRangeSort(b *testing.B) {
+	benchPools(b, func(b *testing.B, newPool func(uint32) Pool, txs []TxDesc) {
+		pool := fillPool(b, newPool, txs)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			_ = pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+				return false, nil
+			})
+		}
+	})
+}
+
+// BenchmarkPoolSelect measures selecting the 1000 highest fee txs of a pool
+// of 100k txs, as the block generator does
+func BenchmarkPoolSelect(b *testing.B) {
+	benchPools(b, func(b *testing.B, newPool func(uint32) Pool, txs []TxDesc) {
+		pool := fillPool(b, newPool, txs)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			count := 0
+			_ = pool.RangeSort(func(k txHash, t TxDesc) (bool, error) {
+				count++
+				return count == 1000, nil
+			})
+		}
+	})
+}
+
+// BenchmarkPoolEvict measures evicting the 10k lowest fee txs of a pool of
+// 100k txs
+func BenchmarkPoolEvict(b *testing.B) {
+	benchPools(b, func(b *testing.B, newPool func(uint32) Pool, txs []TxDesc) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			pool := fillPool(b, newPool, txs)
+			b.StartTimer()
+
+			for i := 0; i < benchPoolTxs/10; i++ {
+				k, _, _ := pool.LowestFee()
+				pool.Delete(k[:])
+			}
+		}
+	})
+}
@@ -25,8 +25,10 @@ type (
 		// Block Generator to fetch highest-fee txs without delays in sorting
 		sorted []keyFee
 
-		// spent key images from the transactions in the pool
-		//spentkeyImages map[keyImage]bool
+		// spent indexes the nullifiers of the pooled txs by the key of the
+		// tx spending them
+		spent map[string]txHash
+
 		Capacity uint32
 		txsSize  uint32
 	}
@@ -40,6 +42,7 @@ func (m *HashMap) Put(t TxDesc) error {
 	if m.data == nil {
 		m.data = make(map[txHash]TxDesc, m.Capacity)
 		m.sorted = make([]keyFee, 0, m.Capacity)
+		m.spent = make(map[string]txHash, m.Capacity)
 	}
 
 	// store tx
@@ -54,6 +57,10 @@ func (m *HashMap) Put(t TxDesc) error {
 
 	m.txsSize += uint32(t.size)
 
+	for _, n := range t.tx.StandardTx().Nullifiers {
+		m.spent[string(n.Data)] = k
+	}
+
 	// sort keys by Fee
 	// Bulk sort like (sort.Slice) performs a few times slower than
 	// a simple binarysearch&shift algorithm.
@@ -109,6 +116,62 @@ func (m *HashMap) Contains(txID []byte) bool {
 	return ok
 }
 
+// Delete removes a tx for a given txID if it exists.
+func (m *HashMap) Delete(txID []byte) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	var k txHash
+	copy(k[:], txID)
+	t, ok := m.data[k]
+	if !ok {
+		return false
+	}
+
+	delete(m.data, k)
+	m.txsSize -= uint32(t.size)
+
+	for _, n := range t.tx.StandardTx().Nullifiers {
+		if m.spent[string(n.Data)] == k {
+			delete(m.spent, string(n.Data))
+		}
+	}
+
+	// entries with the same fee are contiguous in the sorted keys
+	_, fee := t.tx.Values()
+	from := sort.Search(len(m.sorted), func(i int) bool {
+		return m.sorted[i].f <= fee
+	})
+
+	for i := from; i < len(m.sorted) && m.sorted[i].f == fee; i++ {
+		if m.sorted[i].k == k {
+			m.sorted = append(m.sorted[:i], m.sorted[i+1:]...)
+			break
+		}
+	}
+
+	return true
+}
+
+// SpentBy returns the key of the tx spending a nullifier, if any
+func (m *HashMap) SpentBy(nullifier []byte) (txHash, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	k, ok := m.spent[string(nullifier)]
+	return k, ok
+}
+
+// LowestFee returns the entry at the end of the sorted keys
+func (m *HashMap) LowestFee() (txHash, TxDesc, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	if len(m.sorted) == 0 {
+		return txHash{}, TxDesc{}, false
+	}
+
+	k := m.sorted[len(m.sorted)-1].k
+	return k, m.data[k], true
+}
+
 // Get returns a tx for a given txID if it exists.
 func (m *HashMap) Get(txID []byte) transactions.ContractCall {
 	m.lock.RLock()
@@ -122,6 +185,16 @@ func (m *HashMap) Get(txID []byte) transactions.ContractCall {
 	return txd.tx
 }
 
+// GetDesc retrieves the TxDesc of a given txID, if it exists.
+func (m *HashMap) GetDesc(txID []byte) (TxDesc, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var k txHash
+	copy(k[:], txID)
+	txd, ok := m.data[k]
+	return txd, ok
+}
+
 // Size of the txs
 func (m *HashMap) Size() uint32 {
 	m.lock.RLock()
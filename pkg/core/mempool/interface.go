@@ -32,8 +32,17 @@ type Pool interface {
 	Put(t TxDesc) error
 	// Get retrieves a transaction for a given txID, if it exists.
 	Get(txID []byte) transactions.ContractCall
+	// GetDesc retrieves the TxDesc of a given txID. The last return value
+	// is false if it is not in the pool
+	GetDesc(txID []byte) (TxDesc, bool)
 	// Contains returns true if the given key is in the pool.
 	Contains(key []byte) bool
+	// Delete removes the transaction with the given txID. It returns false
+	// if it is not in the pool
+	Delete(txID []byte) bool
+	// SpentBy returns the key of the entry spending the given nullifier. The
+	// last return value is false if no entry spends it
+	SpentBy(nullifier []byte) (txHash, bool)
 	// Clone the entire pool
 	Clone() []transactions.ContractCall
 
@@ -51,4 +60,9 @@ type Pool interface {
 	// RangeSort iterates through all tx entries sorted by Fee
 	// in a descending order
 	RangeSort(fn func(k txHash, t TxDesc) (bool, error)) error
+
+	// LowestFee returns the entry with the lowest Fee. If more entries share
+	// it, the last received one is returned. The last return value is false
+	// if the pool is empty
+	LowestFee() (txHash, TxDesc, bool)
 }
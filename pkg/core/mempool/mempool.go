@@ -17,6 +17,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
@@ -46,9 +47,14 @@ var (
 // Mempool is a storage for the chain transactions that are valid according to the
 // current chain state and can be included in the next block.
 type Mempool struct {
-	getMempoolTxsChan       <-chan rpcbus.Request
-	getMempoolTxsBySizeChan <-chan rpcbus.Request
-	sendTxChan              <-chan rpcbus.Request
+	getMempoolTxsChan        <-chan rpcbus.Request
+	getMempoolTxsBySizeChan  <-chan rpcbus.Request
+	sendTxChan               <-chan rpcbus.Request
+	estimateFeeChan          <-chan rpcbus.Request
+	getVerificationStatsChan <-chan rpcbus.Request
+
+	// verifies the submitted txs concurrently
+	verification *verificationPool
 
 	// verified txs to be included in next block
 	verified Pool
@@ -59,11 +65,21 @@ type Mempool struct {
 	// used by tx verification procedure
 	latestBlockTimestamp int64
 
+	// tracks the fees of the accepted blocks
+	estimator *feeEstimator
+
 	eventBus *eventbus.EventBus
 
 	// the magic function that knows best what is valid chain Tx
 	verifier transactions.UnconfirmedTxProber
+
+	// scores the peers sending invalid txs
+	reputationLock sync.RWMutex
+	reputation     Reputation
+
 	quitChan chan struct{}
+	// closed once the main loop terminated
+	stopped chan struct{}
 
 	ctx context.Context
 }
@@ -97,28 +113,45 @@ func NewMempool(ctx context.Context, eventBus *eventbus.EventBus, rpcBus *rpcbus
 		log.WithError(err).Error("failed to register topics.SendMempoolTx")
 	}
 
+	estimateFeeChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.EstimateFee, estimateFeeChan); err != nil {
+		log.WithError(err).Error("failed to register topics.EstimateFee")
+	}
+
+	getVerificationStatsChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topics.GetVerificationStats, getVerificationStatsChan); err != nil {
+		log.WithError(err).Error("failed to register topics.GetVerificationStats")
+	}
+
 	acceptedBlockChan, _ := consensus.InitAcceptedBlockUpdate(eventBus)
 
 	m := &Mempool{
-		ctx:                     ctx,
-		eventBus:                eventBus,
-		latestBlockTimestamp:    math.MinInt32,
-		quitChan:                make(chan struct{}),
-		acceptedBlockChan:       acceptedBlockChan,
-		getMempoolTxsChan:       getMempoolTxsChan,
-		getMempoolTxsBySizeChan: getMempoolTxsBySizeChan,
-		sendTxChan:              sendTxChan,
-		verifier:                verifier,
-	}
+		ctx:                      ctx,
+		eventBus:                 eventBus,
+		latestBlockTimestamp:     math.MinInt32,
+		quitChan:                 make(chan struct{}),
+		acceptedBlockChan:        acceptedBlockChan,
+		getMempoolTxsChan:        getMempoolTxsChan,
+		getMempoolTxsBySizeChan:  getMempoolTxsBySizeChan,
+		sendTxChan:               sendTxChan,
+		estimateFeeChan:          estimateFeeChan,
+		getVerificationStatsChan: getVerificationStatsChan,
+		verifier:                 verifier,
+		estimator:                &feeEstimator{},
+	}
+
+	m.verification = newVerificationPool(m)
 
 	// Setting the pool where to cache verified transactions.
-	// The pool is normally a Hashmap
+	// The pool is normally a Hashmap, or a FeeHeap
 	m.verified = m.newPool()
 
 	log.Infof("Running with pool type %s", config.Get().Mempool.PoolType)
 
 	if srv != nil {
 		node.RegisterMempoolServer(srv, m)
+		RegisterFeeEstimatorServer(srv, m)
+		RegisterMempoolEventsServer(srv, m)
 	}
 	return m
 }
@@ -130,59 +163,166 @@ func NewMempool(ctx context.Context, eventBus *eventbus.EventBus, rpcBus *rpcbus
 // All operations are always executed in a single go-routine so no
 // protection-by-mutex needed
 func (m *Mempool) Run() {
+	m.stopped = make(chan struct{})
+	m.verification.start()
 	go func() {
+		defer close(m.stopped)
+
+		persist := config.Get().Mempool.Persist
+		var journal <-chan time.Time
+		if persist {
+			// txs dumped at the last shutdown are verified again against the
+			// current state
+			m.load()
+
+			if interval := journalInterval(); interval > 0 {
+				ticker := time.NewTicker(interval)
+				defer ticker.Stop()
+				journal = ticker.C
+			}
+		}
+
 		for {
 			select {
 			//rpcbus methods
 			case r := <-m.sendTxChan:
-				handleRequest(r, m.processSendMempoolTxRequest, "SendTx")
+				m.processSendMempoolTxRequest(r)
+			case j := <-m.verification.received:
+				m.verifyReceived(j.t, j.srcPeer)
+			case j := <-m.verification.verified:
+				m.commitVerified(j)
 			case r := <-m.getMempoolTxsChan:
 				handleRequest(r, m.processGetMempoolTxsRequest, "GetMempoolTxs")
 			case r := <-m.getMempoolTxsBySizeChan:
 				handleRequest(r, m.processGetMempoolTxsBySizeRequest, "GetMempoolTxsBySize")
+			case r := <-m.estimateFeeChan:
+				handleRequest(r, m.processEstimateFeeRequest, "EstimateFee")
+			case r := <-m.getVerificationStatsChan:
+				handleRequest(r, m.processGetVerificationStatsRequest, "GetVerificationStats")
 			case b := <-m.acceptedBlockChan:
 				m.onBlock(b)
 			case <-time.After(20 * time.Second):
 				m.onIdle()
+			case <-journal:
+				m.persist()
 			// Mempool terminating
 			case <-m.quitChan:
 				//m.eventBus.Unsubscribe(topics.Tx, m.txSubscriberID)
+				m.verification.halt()
+				if persist {
+					m.persist()
+				}
 				return
 			}
 		}
 	}()
 }
 
-// ProcessTx handles a submitted tx from any source (rpcBus or eventBus)
-func (m *Mempool) ProcessTx(msg message.Message) ([]bytes.Buffer, error) {
+// ProcessTx handles a tx received from the network. It returns once the tx is
+// queued, so that the peer is not stalled by the verification. The mempool
+// checks run on the main loop, which takes the tx from the queue. It complies
+// with the peer.PeerProcessorFunc interface
+func (m *Mempool) ProcessTx(srcPeer string, msg message.Message) ([]bytes.Buffer, error) {
 	t := TxDesc{tx: msg.Payload().(transactions.ContractCall), received: time.Now(), size: uint(len(msg.Id()))}
 	log.Info("handle submitted tx")
 
-	start := time.Now()
-	txid, err := m.processTx(t)
-	elapsed := time.Since(start)
+	if err := m.verification.receive(t, srcPeer); err != nil {
+		log.WithError(err).Warn("Failed to queue submitted tx")
+	}
 
-	if err != nil {
-		log.WithError(err).
-			WithField("txid", toHex(txid)).
-			WithField("duration", elapsed.Microseconds()).
-			Error("Failed handle submitted tx")
-	} else {
-		log.WithError(err).
-			WithField("txid", toHex(txid)).
-			WithField("duration", elapsed.Microseconds()).
-			Infof("Verified handle submitted tx")
+	return nil, nil
+}
+
+// verifyReceived submits a tx received from srcPeer to the verification pool.
+// It runs on the main loop
+func (m *Mempool) verifyReceived(t TxDesc, srcPeer string) {
+	report := func(txid []byte, err error) {
+		l := log.WithField("txid", toHex(txid)).
+			WithField("duration", time.Since(t.received).Microseconds())
+
+		switch {
+		case err == nil:
+			l.Infof("Verified handle submitted tx")
+		case isPoolRejection(err):
+			l.WithError(err).Debug("Rejected handle submitted tx")
+		default:
+			l.WithError(err).Error("Failed handle submitted tx")
+		}
+	}
+
+	if txid, err := m.verification.submit(t, Network, srcPeer, report); err != nil {
+		report(txid, err)
 	}
+}
 
-	return nil, err
+// SetReputation sets the Reputation the peers sending txs rejected by the
+// verifier are reported to
+func (m *Mempool) SetReputation(r Reputation) {
+	m.reputationLock.Lock()
+	defer m.reputationLock.Unlock()
+	m.reputation = r
+}
+
+// invalidTx reports srcPeer for sending an invalid tx, and disconnects it if
+// its Reputation requires so. The txs rejected because of the state of the
+// pool are not reported, as the peer might not know about it
+func (m *Mempool) invalidTx(srcPeer string) {
+	m.reputationLock.RLock()
+	r := m.reputation
+	m.reputationLock.RUnlock()
+	if r == nil || srcPeer == "" {
+		return
+	}
+
+	if r.Misbehaved(srcPeer, peer.InvalidTx) {
+		log.WithField("address", srcPeer).
+			Warnln("disconnecting peer sending invalid txs")
+		_ = r.Disconnect(srcPeer)
+	}
+}
+
+// isPoolRejection tells if err rejects a tx because of the state of the
+// mempool, rather than because the tx is invalid
+func isPoolRejection(err error) bool {
+	return errors.Is(err, ErrAlreadyExists) ||
+		errors.Is(err, ErrDoubleSpending) ||
+		errors.Is(err, ErrMempoolFull) ||
+		errors.Is(err, ErrQueueFull) ||
+		errors.Is(err, errStopped)
 }
 
 // processTx ensures all transaction rules are satisfied before adding the tx
-// into the verified pool
+// into the verified pool. Unlike the txs submitted to the verification pool,
+// the tx is verified synchronously
 func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
+	txid, err := m.verifyAndCommit(t)
+	if err != nil {
+		m.notifyRejected(t, txid, err)
+	}
+
+	return txid, err
+}
+
+func (m *Mempool) verifyAndCommit(t TxDesc) ([]byte, error) {
+	txid, replaced, err := m.precheck(t)
+	if err != nil {
+		return txid, err
+	}
+
+	// execute tx verification procedure
+	if err := m.checkTx(t.tx); err != nil {
+		return txid, fmt.Errorf("verification: %v", err)
+	}
+
+	return txid, m.commit(t, txid, replaced)
+}
+
+// precheck runs the checks which do not need the verifier, and returns the
+// pooled txs which t replaces. It does not modify the pool
+func (m *Mempool) precheck(t TxDesc) ([]byte, []txHash, error) {
 	txid, err := t.tx.CalculateHash()
 	if err != nil {
-		return txid, fmt.Errorf("hash err: %s", err.Error())
+		return txid, nil, fmt.Errorf("hash err: %s", err.Error())
 	}
 
 	log.WithField("txid", txid).
@@ -191,31 +331,55 @@ func (m *Mempool) processTx(t TxDesc) ([]byte, error) {
 
 	if t.tx.Type() == transactions.Distribute {
 		// coinbase tx should be built by block generator only
-		return txid, ErrCoinbaseTxNotAllowed
+		return txid, nil, ErrCoinbaseTxNotAllowed
 	}
 
 	// expect it is not already a verified tx
 	if m.verified.Contains(txid) {
-		return txid, ErrAlreadyExists
+		return txid, nil, ErrAlreadyExists
 	}
 
-	// execute tx verification procedure
-	if err := m.checkTx(t.tx); err != nil {
-		return txid, fmt.Errorf("verification: %v", err)
+	// a tx spending the nullifiers of pooled txs must pay enough to replace
+	// them. Other double spends are rejected here, without reaching Rusk
+	replaced, err := m.checkReplacement(t)
+	if err != nil {
+		return txid, nil, err
+	}
+
+	// a full pool only accepts txs paying more than its lowest fee
+	if err := m.checkCapacity(t); err != nil {
+		return txid, nil, err
 	}
 
+	return txid, replaced, nil
+}
+
+// commit a verified tx into the pool, evicting the txs it replaces
+func (m *Mempool) commit(t TxDesc, txid []byte, replaced []txHash) error {
 	// if consumer's verification passes, mark it as verified
 	t.verified = time.Now()
 
+	for _, k := range replaced {
+		m.evict(k, Replaced)
+	}
+
 	// we've got a valid transaction pushed
 	if err := m.verified.Put(t); err != nil {
-		return txid, fmt.Errorf("store: %v", err)
+		return fmt.Errorf("store: %v", err)
 	}
 
+	// make room for it by evicting the lowest fee txs
+	m.evictOverBudget()
+	if !m.verified.Contains(txid) {
+		return ErrMempoolFull
+	}
+
+	m.publishTxEvent(newTxEvent(Added, t, txid, ""))
+
 	// try to (re)propagate transaction in both gossip and kadcast networks
 	m.propagateTx(t, txid)
 
-	return txid, nil
+	return nil
 }
 
 // propagateTx (re)-propagate tx in gossip or kadcast network but not in both
@@ -236,6 +400,7 @@ func (m *Mempool) propagateTx(t TxDesc, txid []byte) {
 
 func (m *Mempool) onBlock(b block.Block) {
 	m.latestBlockTimestamp = b.Header.Timestamp
+	m.estimator.onBlock(b)
 	m.removeAccepted(b)
 }
 
@@ -266,18 +431,36 @@ func (m *Mempool) removeAccepted(b block.Block) {
 		payloads[i] = tx.(merkletree.Payload)
 	}
 
+	// nullifiers spent by the block txs
+	spent := make(map[string]struct{})
+	for _, tx := range b.Txs {
+		for _, n := range tx.StandardTx().Nullifiers {
+			spent[string(n.Data)] = struct{}{}
+		}
+	}
+
 	tree, err := merkletree.NewTree(payloads)
 	if err == nil && tree != nil {
 		s := m.newPool()
+		included := make(map[txHash]TxDesc)
+		doubleSpent := make(map[txHash]TxDesc)
 		// Check if mempool verified tx is part of merkle tree of this block
-		// if not, then keep it in the mempool for the next block
+		// if not, then keep it in the mempool for the next block, unless it
+		// spends the same nullifiers as a block tx
 		err = m.verified.Range(func(k txHash, t TxDesc) error {
-			if r, _ := tree.VerifyContent(t.tx); !r {
-				if e := s.Put(t); e != nil {
-					return e
+			if r, _ := tree.VerifyContent(t.tx); r {
+				included[k] = t
+				return nil
+			}
+
+			for _, n := range t.tx.StandardTx().Nullifiers {
+				if _, ok := spent[string(n.Data)]; ok {
+					doubleSpent[k] = t
+					return nil
 				}
 			}
-			return nil
+
+			return s.Put(t)
 		})
 
 		if err != nil {
@@ -290,6 +473,13 @@ func (m *Mempool) removeAccepted(b block.Block) {
 		}
 
 		m.verified = s
+		for k, t := range included {
+			m.publishTxEvent(newTxEvent(Included, t, k[:], ""))
+		}
+
+		for k, t := range doubleSpent {
+			m.notifyEvicted(k, t, DoubleSpent)
+		}
 	}
 
 	log.
@@ -305,14 +495,23 @@ func (m *Mempool) onIdle() {
 	poolSize := float32(m.verified.Size()) / 1000
 	log.WithField("txs_count", m.verified.Len()).WithField("pool_size", poolSize).Infof("stats to log")
 
-	// trigger alarms/notifications in case of abnormal state
+	for _, q := range m.verification.stats().Queues {
+		log.WithField("source", q.Source).
+			WithField("depth", q.Depth).
+			WithField("rejected", q.Rejected).
+			WithField("avg_wait_ms", q.AvgWaitMs).
+			WithField("avg_verify_ms", q.AvgVerifyMs).
+			Info("verification queue stats")
+	}
 
-	// trigger alarms on too much txs memory allocated
-	maxSizeBytes := config.Get().Mempool.MaxSizeMB * 1000 * 1000
-	if m.verified.Size() > maxSizeBytes {
+	// get rid of stuck/expired transactions, and of the lowest fee ones if
+	// the pool is still too big
+	m.expireTxs(time.Now())
+	if m.verified.Size() > maxSizeBytes() {
 		log.WithField("max_size_mb", config.Get().Mempool.MaxSizeMB).
 			WithField("current_size", m.verified.Size()).
 			Warn("Mempool is too big")
+		m.evictOverBudget()
 	}
 
 	if log.Logger.Level == logger.TraceLevel {
@@ -324,8 +523,6 @@ func (m *Mempool) onIdle() {
 		}
 	}
 
-	// TODO: Get rid of stuck/expired transactions
-
 	// TODO: Check periodically the oldest txs if somehow were accepted into the
 	// blockchain but were not removed from mempool verified list.
 	/*()
@@ -343,6 +540,8 @@ func (m *Mempool) newPool() Pool {
 	switch config.Get().Mempool.PoolType {
 	case "hashmap":
 		p = &HashMap{lock: &sync.RWMutex{}, Capacity: preallocTxs}
+	case "heap":
+		p = NewFeeHeap(preallocTxs)
 	case "syncpool":
 		log.Panic("syncpool not supported")
 	default:
@@ -507,21 +706,44 @@ func (m Mempool) processGetMempoolTxsBySizeRequest(r rpcbus.Request) (interface{
 	return txs, err
 }
 
-// processSendMempoolTxRequest utilizes rpcbus to allow submitting a tx to mempool with
-func (m Mempool) processSendMempoolTxRequest(r rpcbus.Request) (interface{}, error) {
+// processSendMempoolTxRequest utilizes rpcbus to allow submitting a tx to mempool with.
+// The request is answered once the tx went through the verification pool, so
+// that the main loop is not blocked meanwhile
+func (m *Mempool) processSendMempoolTxRequest(r rpcbus.Request) {
+	respond := func(txid []byte, err error) {
+		if err != nil {
+			log.
+				WithError(err).
+				WithField("name", "SendTx").Errorf("mempool failed to process request")
+			r.RespChan <- rpcbus.Response{Err: err}
+			return
+		}
+
+		r.RespChan <- rpcbus.Response{Resp: txid, Err: nil}
+	}
+
 	tx := r.Params.(transactions.ContractCall)
 	buf := new(bytes.Buffer)
 	if err := transactions.Marshal(buf, tx); err != nil {
-		return nil, err
+		respond(nil, err)
+		return
 	}
 
 	t := TxDesc{tx: tx, received: time.Now(), size: uint(buf.Len()), kadHeight: kadcast.InitHeight}
-	return m.processTx(t)
+	if txid, err := m.verification.submit(t, Local, "", respond); err != nil {
+		respond(txid, err)
+	}
 }
 
-// Quit makes mempool main loop to terminate
+// Quit makes mempool main loop to terminate. It returns once the pool got
+// dumped, if persistence is enabled. It is a no-op if the mempool never ran
 func (m *Mempool) Quit() {
+	if m.stopped == nil {
+		return
+	}
+
 	m.quitChan <- struct{}{}
+	<-m.stopped
 }
 
 // Send Inventory message to all peers
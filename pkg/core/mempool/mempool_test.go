@@ -57,6 +57,7 @@ func TestMain(m *testing.M) {
 	r.Mempool.MaxSizeMB = 1
 	r.Mempool.PoolType = "hashmap"
 	r.Mempool.MaxInvItems = 10000
+	r.Mempool.ReplaceByFeeMargin = 10
 	config.Mock(&r)
 
 	var streamer *eventbus.GossipStreamer
@@ -150,26 +151,30 @@ func TestProcessPendingTxs(t *testing.T) {
 	c.reset()
 
 	cc := transactions.RandContractCalls(10, 0, false)
+	spendRandom(cc...)
 
 	for i := 0; i < 5; i++ {
 		// Publish valid tx
 		txMsg := prepTx(cc[i])
 		c.addTx(cc[i])
-		_, errList := c.m.ProcessTx(txMsg)
+		_, errList := c.m.ProcessTx("", txMsg)
 		assert.Empty(t, errList)
 
 		// Publish invalid/valid txs (ones that do not pass verifyTx and ones that do)
 		invalid := transactions.RandContractCall()
+		spendRandom(invalid)
 		transactions.Invalidate(invalid)
+		// The peers are not told about the verdict, which comes after the
+		// tx is queued
 		txMsg = prepTx(invalid)
 		c.addTx(invalid)
-		_, errList = c.m.ProcessTx(txMsg)
-		assert.NotEmpty(t, errList)
+		_, errList = c.m.ProcessTx("", txMsg)
+		assert.Empty(t, errList)
 
 		// Publish a duplicated tx
 		c.addTx(invalid)
-		_, errList = c.m.ProcessTx(txMsg)
-		assert.NotEmpty(t, errList)
+		_, errList = c.m.ProcessTx("", txMsg)
+		assert.Empty(t, errList)
 	}
 
 	c.assert(t, true)
@@ -189,6 +194,7 @@ func TestProcessPendingTxsAsync(t *testing.T) {
 	for i := 0; i <= batchCount; i++ {
 		// Generate a single batch of txs and added to the expected list of verified
 		txs := transactions.RandContractCalls(4, 0, false)
+		spendRandom(txs...)
 		for _, tx := range txs {
 			c.addTx(tx)
 		}
@@ -206,7 +212,7 @@ func TestProcessPendingTxsAsync(t *testing.T) {
 		go func(txs []transactions.ContractCall) {
 			for _, tx := range txs {
 				txMsg := prepTx(tx)
-				_, errList := c.m.ProcessTx(txMsg)
+				_, errList := c.m.ProcessTx("", txMsg)
 				assert.Empty(t, errList)
 			}
 			wg.Done()
@@ -220,8 +226,8 @@ func TestProcessPendingTxsAsync(t *testing.T) {
 			for y := 0; y <= 5; y++ {
 				tx := transactions.MockInvalidTx()
 				txMsg := prepTx(tx)
-				_, errList := c.m.ProcessTx(txMsg)
-				assert.NotEmpty(t, errList)
+				_, errList := c.m.ProcessTx("", txMsg)
+				assert.Empty(t, errList)
 			}
 			wg.Done()
 		}()
@@ -246,6 +252,7 @@ func TestRemoveAccepted(t *testing.T) {
 
 	// generate 3*4 random txs
 	txs := transactions.RandContractCalls(12, 0, false)
+	spendRandom(txs...)
 	for _, tx := range txs {
 		// We avoid sharing this pointer between the mempool and the block
 		// by marshaling and unmarshaling the tx
@@ -259,7 +266,7 @@ func TestRemoveAccepted(t *testing.T) {
 		txMsg := prepTx(txCopy)
 
 		// Publish valid tx
-		_, errList := c.m.ProcessTx(txMsg)
+		_, errList := c.m.ProcessTx("", txMsg)
 		assert.Empty(errList)
 
 		// Simulate a situation where the block has accepted each 2nd tx
@@ -290,14 +297,13 @@ func TestCoinbaseTxsNotAllowed(t *testing.T) {
 
 	// Publish a set of valid txs and a Coinbase one
 	txs := transactions.RandContractCalls(5, 0, true)
+	spendRandom(txs...)
 
 	for _, tx := range txs {
 		txMsg := prepTx(tx)
 		c.addTx(tx)
-		_, errList := c.m.ProcessTx(txMsg)
-		if tx.Type() == transactions.Distribute {
-			assert.NotEmpty(t, errList)
-		}
+		_, errList := c.m.ProcessTx("", txMsg)
+		assert.Empty(t, errList)
 	}
 
 	c.wait()
@@ -312,6 +318,7 @@ func TestSendMempoolTx(t *testing.T) {
 	c.reset()
 
 	txs := transactions.RandContractCalls(4, 0, false)
+	spendRandom(txs...)
 
 	var totalSize uint32
 	for _, tx := range txs {
@@ -0,0 +1,164 @@
+package mempool
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+)
+
+// dumpVersion is the version of the mempool dump format
+const dumpVersion uint8 = 1
+
+// DumpFile returns the path of the mempool dump, as configured or next to the
+// chain database
+func DumpFile() string {
+	if path := config.Get().Mempool.DumpFile; path != "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "mempool.dat")
+}
+
+func journalInterval() time.Duration {
+	return time.Duration(config.Get().Mempool.JournalInterval) * time.Second
+}
+
+// dump writes the verified txs to path. The dump is written to a temporary
+// file first, so that a crash while dumping does not corrupt the previous one
+func (m *Mempool) dump(path string) (int, error) {
+	buf := new(bytes.Buffer)
+	if err := encoding.WriteUint8(buf, dumpVersion); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err := m.verified.Range(func(k txHash, t TxDesc) error {
+		txBuf := new(bytes.Buffer)
+		if err := transactions.Marshal(txBuf, t.tx); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(buf, uint64(t.received.UnixNano())); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint8(buf, t.kadHeight); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteVarBytes(buf, txBuf.Bytes()); err != nil {
+			return err
+		}
+
+		count++
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return 0, err
+	}
+
+	return count, os.Rename(tmp, path)
+}
+
+// restore reads the txs dumped at path and runs each of them through
+// processTx, so that only the ones still valid against the current state
+// make it back into the pool. It returns the amount of restored txs
+func (m *Mempool) restore(path string) (int, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	buf := bytes.NewBuffer(data)
+	var version uint8
+	if err := encoding.ReadUint8(buf, &version); err != nil {
+		return 0, err
+	}
+
+	if version != dumpVersion {
+		return 0, errors.New("unknown mempool dump version")
+	}
+
+	restored := 0
+	for buf.Len() > 0 {
+		var received uint64
+		if err := encoding.ReadUint64LE(buf, &received); err != nil {
+			return restored, err
+		}
+
+		var kadHeight uint8
+		if err := encoding.ReadUint8(buf, &kadHeight); err != nil {
+			return restored, err
+		}
+
+		var txBytes []byte
+		if err := encoding.ReadVarBytes(buf, &txBytes); err != nil {
+			return restored, err
+		}
+
+		tx := transactions.NewTransaction()
+		if err := transactions.Unmarshal(bytes.NewBuffer(txBytes), tx); err != nil {
+			return restored, err
+		}
+
+		t := TxDesc{
+			tx:        tx,
+			received:  time.Unix(0, int64(received)),
+			size:      uint(len(txBytes)),
+			kadHeight: kadHeight,
+		}
+
+		// txs spent or invalidated while the node was down are dropped
+		if txid, err := m.processTx(t); err != nil {
+			log.WithError(err).
+				WithField("txid", toHex(txid)).
+				Debug("dropping dumped transaction")
+			continue
+		}
+
+		restored++
+	}
+
+	return restored, nil
+}
+
+// persist dumps the pool to the configured DumpFile
+func (m *Mempool) persist() {
+	path := DumpFile()
+	count, err := m.dump(path)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Error("could not dump the mempool")
+		return
+	}
+
+	log.WithField("path", path).WithField("txs_count", count).Info("mempool dumped")
+}
+
+// load restores the pool from the configured DumpFile
+func (m *Mempool) load() {
+	path := DumpFile()
+	count, err := m.restore(path)
+	if err != nil {
+		log.WithError(err).WithField("path", path).WithField("txs_count", count).Error("could not restore the mempool")
+		return
+	}
+
+	log.WithField("path", path).WithField("txs_count", count).Info("mempool restored")
+}
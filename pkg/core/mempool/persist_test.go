@@ -0,0 +1,82 @@
+package mempool
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestDumpRestore tests that the dumped txs are verified and put back into
+// an empty pool, keeping their reception time
+func TestDumpRestore(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "mempool")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mempool.dat")
+
+	m, _ := newEvictionMempool()
+	received := time.Now().Add(-time.Minute)
+	txs := []*transactions.Transaction{transactions.RandTx(), transactions.RandTx()}
+	spendRandom(txs[0], txs[1])
+	for _, tx := range txs {
+		_, err := m.processTx(TxDesc{tx: tx, received: received, size: 100})
+		assert.NoError(err)
+	}
+
+	count, err := m.dump(path)
+	assert.NoError(err)
+	assert.Equal(2, count)
+
+	restored, _ := newEvictionMempool()
+	count, err = restored.restore(path)
+	assert.NoError(err)
+	assert.Equal(2, count)
+	assert.Equal(2, restored.verified.Len())
+
+	for _, tx := range txs {
+		txid, _ := tx.CalculateHash()
+		assert.True(restored.verified.Contains(txid))
+	}
+
+	_ = restored.verified.Range(func(k txHash, t TxDesc) error {
+		assert.Equal(received.UnixNano(), t.received.UnixNano())
+		return nil
+	})
+
+	// restoring again only yields already known txs
+	count, err = restored.restore(path)
+	assert.NoError(err)
+	assert.Equal(0, count)
+}
+
+// TestRestoreMissingDump tests that a missing dump restores nothing
+func TestRestoreMissingDump(t *testing.T) {
+	m, _ := newEvictionMempool()
+	count, err := m.restore(filepath.Join(os.TempDir(), "no-such-mempool.dat"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+// TestQuitWithoutRun tests that quitting a mempool which never ran does not
+// block
+func TestQuitWithoutRun(t *testing.T) {
+	m, _ := newEvictionMempool()
+
+	done := make(chan struct{})
+	go func() {
+		m.Quit()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Quit blocked on a mempool which never ran")
+	}
+}
@@ -0,0 +1,87 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+)
+
+// conflicts returns the keys of the pooled txs spending any of the
+// nullifiers of t
+func (m *Mempool) conflicts(t TxDesc) []txHash {
+	keys := make([]txHash, 0)
+	seen := make(map[txHash]struct{})
+	for _, n := range t.tx.StandardTx().Nullifiers {
+		k, ok := m.verified.SpentBy(n.Data)
+		if !ok {
+			continue
+		}
+
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// checkReplacement returns the pooled txs which t replaces. A tx spending the
+// nullifiers of pooled txs replaces them only if its fee beats their total
+// fee by the configured ReplaceByFeeMargin, otherwise it is rejected as a
+// double spend
+func (m *Mempool) checkReplacement(t TxDesc) ([]txHash, error) {
+	if spendsTwice(t) {
+		return nil, fmt.Errorf("%w: nullifier spent twice by the same tx", ErrDoubleSpending)
+	}
+
+	replaced := m.conflicts(t)
+	if len(replaced) == 0 {
+		return nil, nil
+	}
+
+	var replacedFee uint64
+	found := replaced[:0]
+	for _, k := range replaced {
+		// the nullifier index may outlive the tx it points to, a missing tx
+		// is not replaced
+		d, ok := m.verified.GetDesc(k[:])
+		if !ok {
+			continue
+		}
+
+		_, fee := d.tx.Values()
+		replacedFee += fee
+		found = append(found, k)
+	}
+
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	replaced = found
+
+	margin := config.Get().Mempool.ReplaceByFeeMargin
+	minFee := replacedFee + replacedFee*margin/100
+
+	_, fee := t.tx.Values()
+	if fee <= replacedFee || fee < minFee {
+		return nil, fmt.Errorf("%w: replacement fee %d does not beat %d by %d%%", ErrDoubleSpending, fee, replacedFee, margin)
+	}
+
+	return replaced, nil
+}
+
+// spendsTwice tells if a tx spends the same nullifier more than once
+func spendsTwice(t TxDesc) bool {
+	nullifiers := t.tx.StandardTx().Nullifiers
+	seen := make(map[string]struct{}, len(nullifiers))
+	for _, n := range nullifiers {
+		if _, ok := seen[string(n.Data)]; ok {
+			return true
+		}
+		seen[string(n.Data)] = struct{}{}
+	}
+
+	return false
+}
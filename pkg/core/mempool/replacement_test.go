@@ -0,0 +1,116 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/common"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	assert "github.com/stretchr/testify/require"
+)
+
+// spendRandom makes txs spend random nullifiers, as the mocked txs all spend
+// the same one, and would be rejected as double spends
+func spendRandom(txs ...transactions.ContractCall) {
+	for _, tx := range txs {
+		for _, n := range tx.StandardTx().Nullifiers {
+			n.Data = transactions.Rand32Bytes()
+		}
+	}
+}
+
+// newSpendingTx returns a feeTx spending the given nullifiers
+func newSpendingTx(fee uint64, nullifiers ...[]byte) feeTx {
+	tx := newFeeTx(fee)
+	tx.TxPayload.Nullifiers = make([]*common.BlsScalar, len(nullifiers))
+	for i, n := range nullifiers {
+		tx.TxPayload.Nullifiers[i] = &common.BlsScalar{Data: n}
+	}
+
+	return tx
+}
+
+// TestReplaceByFee tests that a tx spending the nullifiers of pooled txs
+// replaces them only when its fee beats theirs by the configured margin
+func TestReplaceByFee(t *testing.T) {
+	assert := assert.New(t)
+	m, evicted := newEvictionMempool()
+
+	n1, n2 := transactions.Rand32Bytes(), transactions.Rand32Bytes()
+	first, second := newSpendingTx(100, n1), newSpendingTx(100, n2)
+	for _, tx := range []feeTx{first, second} {
+		_, err := m.processTx(TxDesc{tx: tx, received: time.Now(), size: 100})
+		assert.NoError(err)
+	}
+
+	// 10% margin over the 200 paid by both
+	_, err := m.processTx(TxDesc{tx: newSpendingTx(215, n1, n2), received: time.Now(), size: 100})
+	assert.True(errors.Is(err, ErrDoubleSpending))
+	assert.Equal(2, m.verified.Len())
+
+	replacement := newSpendingTx(220, n1, n2)
+	_, err = m.processTx(TxDesc{tx: replacement, received: time.Now(), size: 100})
+	assert.NoError(err)
+	assert.Equal(1, m.verified.Len())
+
+	replacementID, _ := replacement.CalculateHash()
+	k, ok := m.verified.SpentBy(n1)
+	assert.True(ok)
+	assert.Equal(replacementID, k[:])
+
+	for i := 0; i < 2; i++ {
+		e := <-evicted
+		assert.Equal(Replaced, e.Reason)
+	}
+}
+
+// TestLocalDoubleSpendCheck tests that double spends are rejected before
+// reaching the verifier
+func TestLocalDoubleSpendCheck(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+
+	n := transactions.Rand32Bytes()
+	_, err := m.processTx(TxDesc{tx: newSpendingTx(100, n), received: time.Now(), size: 100})
+	assert.NoError(err)
+
+	// the verifier would reject an invalid tx with a verification error
+	conflicting := newSpendingTx(100, n)
+	transactions.Invalidate(conflicting)
+	_, err = m.processTx(TxDesc{tx: conflicting, received: time.Now(), size: 100})
+	assert.True(errors.Is(err, ErrDoubleSpending))
+
+	twice := newSpendingTx(100, transactions.Rand32Bytes())
+	twice.TxPayload.Nullifiers = append(twice.TxPayload.Nullifiers, twice.TxPayload.Nullifiers[0])
+	_, err = m.processTx(TxDesc{tx: twice, received: time.Now(), size: 100})
+	assert.True(errors.Is(err, ErrDoubleSpending))
+}
+
+// TestRemoveDoubleSpent tests that accepting a block drops the pooled txs
+// spending the nullifiers of the block txs, along with their index entries
+func TestRemoveDoubleSpent(t *testing.T) {
+	assert := assert.New(t)
+	m, evicted := newEvictionMempool()
+
+	n := transactions.Rand32Bytes()
+	pooled, kept := newSpendingTx(100, n), newSpendingTx(100, transactions.Rand32Bytes())
+	for _, tx := range []feeTx{pooled, kept} {
+		_, err := m.processTx(TxDesc{tx: tx, received: time.Now(), size: 100})
+		assert.NoError(err)
+	}
+
+	blk := block.NewBlock()
+	blk.Txs = []transactions.ContractCall{newSpendingTx(50, n).Transaction}
+	m.removeAccepted(*blk)
+
+	assert.Equal(1, m.verified.Len())
+	_, ok := m.verified.SpentBy(n)
+	assert.False(ok)
+
+	pooledID, _ := pooled.CalculateHash()
+	e := <-evicted
+	assert.Equal(pooledID, e.TxID)
+	assert.Equal(DoubleSpent, e.Reason)
+}
@@ -0,0 +1,375 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+const (
+	// DefaultVerifyWorkers is the amount of concurrent verifications when no
+	// VerifyWorkers is configured
+	DefaultVerifyWorkers = 4
+	// DefaultVerifyQueueSize is the capacity of each verification queue when
+	// no VerifyQueueSize is configured
+	DefaultVerifyQueueSize = 1000
+)
+
+// ErrQueueFull is returned when the verification queue of a TxSource is full
+var ErrQueueFull = errors.New("verification queue is full")
+
+var errStopped = errors.New("mempool is not running")
+
+// TxSource identifies where a tx comes from. Each source has its own
+// verification queue, so that a flood from one of them does not starve the
+// others
+type TxSource string
+
+const (
+	// Network txs are received from the peers
+	Network TxSource = "network"
+	// Local txs are submitted through the node API (e.g. by the wallet)
+	Local TxSource = "local"
+)
+
+// QueueStats are the metrics of the verification queue of a TxSource
+type QueueStats struct {
+	Source   TxSource `json:"source"`
+	Depth    int      `json:"depth"`
+	Capacity int      `json:"capacity"`
+	// Verified is the amount of txs which went through the verification
+	Verified uint64 `json:"verified"`
+	// Rejected is the amount of txs dropped because the queue was full
+	Rejected uint64 `json:"rejected"`
+	// Duplicates is the amount of txs already pooled or being verified
+	Duplicates uint64 `json:"duplicates"`
+	// AvgWaitMs is the average time a tx waited in the queue
+	AvgWaitMs float64 `json:"avg_wait_ms"`
+	// AvgVerifyMs is the average duration of the Rusk verification
+	AvgVerifyMs float64 `json:"avg_verify_ms"`
+}
+
+// VerificationStats are the metrics of the verification worker pool
+type VerificationStats struct {
+	Workers  int          `json:"workers"`
+	InFlight int          `json:"in_flight"`
+	Queues   []QueueStats `json:"queues"`
+}
+
+// Reputation keeps track of the peers sending invalid txs
+type Reputation interface {
+	peer.Reputation
+	// Disconnect closes the connection to the peer at addr
+	Disconnect(addr string) error
+}
+
+type verifyJob struct {
+	t    TxDesc
+	txid []byte
+	// the address of the peer which sent the tx, if any
+	srcPeer string
+	source  TxSource
+	queued  time.Time
+	err     error
+	done    func(txid []byte, err error)
+}
+
+type queueMetrics struct {
+	verified    uint64
+	rejected    uint64
+	duplicates  uint64
+	waitNanos   uint64
+	verifyNanos uint64
+}
+
+// verificationPool verifies txs with a bounded amount of concurrent workers,
+// out of the mempool main loop. Verified txs are handed back to the main
+// loop, which puts them into the pool
+type verificationPool struct {
+	m       *Mempool
+	workers int
+	sources []TxSource
+	queues  map[TxSource]chan *verifyJob
+	metrics map[TxSource]*queueMetrics
+
+	// txs received from the network, waiting for the main loop to submit
+	// them
+	received chan *verifyJob
+
+	// verified txs waiting to be put into the pool by the main loop
+	verified chan *verifyJob
+
+	lock     sync.Mutex
+	inFlight map[txHash]struct{}
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newVerificationPool(m *Mempool) *verificationPool {
+	workers := int(config.Get().Mempool.VerifyWorkers)
+	if workers <= 0 {
+		workers = DefaultVerifyWorkers
+	}
+
+	queueSize := int(config.Get().Mempool.VerifyQueueSize)
+	if queueSize <= 0 {
+		queueSize = DefaultVerifyQueueSize
+	}
+
+	p := &verificationPool{
+		m:        m,
+		workers:  workers,
+		sources:  []TxSource{Local, Network},
+		queues:   make(map[TxSource]chan *verifyJob),
+		metrics:  make(map[TxSource]*queueMetrics),
+		received: make(chan *verifyJob, queueSize),
+		verified: make(chan *verifyJob, workers),
+		inFlight: make(map[txHash]struct{}),
+	}
+
+	for _, s := range p.sources {
+		p.queues[s] = make(chan *verifyJob, queueSize)
+		p.metrics[s] = &queueMetrics{}
+	}
+
+	return p
+}
+
+// start the workers
+func (p *verificationPool) start() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.stop = make(chan struct{})
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.work(p.stop)
+	}
+}
+
+// halt the workers. The queued txs are answered with errStopped
+func (p *verificationPool) halt() {
+	p.lock.Lock()
+	stop := p.stop
+	p.stop = nil
+	p.lock.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	p.wg.Wait()
+
+	for _, s := range p.sources {
+		p.drain(p.queues[s])
+	}
+	p.drain(p.verified)
+
+	// the received txs were not submitted yet
+	for len(p.received) > 0 {
+		<-p.received
+	}
+}
+
+func (p *verificationPool) drain(c chan *verifyJob) {
+	for {
+		select {
+		case j := <-c:
+			p.finish(j, errStopped)
+		default:
+			return
+		}
+	}
+}
+
+// receive queues a tx received from srcPeer, to be submitted by the main
+// loop. It does not touch the pool, so that it can be called from the peer
+// goroutines
+func (p *verificationPool) receive(t TxDesc, srcPeer string) error {
+	p.lock.Lock()
+	stopped := p.stop == nil
+	p.lock.Unlock()
+	if stopped {
+		return errStopped
+	}
+
+	select {
+	case p.received <- &verifyJob{t: t, srcPeer: srcPeer, source: Network, queued: time.Now()}:
+		return nil
+	default:
+		atomic.AddUint64(&p.metrics[Network].rejected, 1)
+		return fmt.Errorf("%w: %s", ErrQueueFull, Network)
+	}
+}
+
+// submit a tx for verification. The txs failing the mempool checks, the
+// duplicates and the ones not fitting in their queue are rejected straight
+// away. Otherwise done is called once the tx is verified and pooled (or
+// rejected). srcPeer is the address of the peer which sent the tx, if any. It
+// runs on the main loop, as the mempool checks read the pool
+func (p *verificationPool) submit(t TxDesc, source TxSource, srcPeer string, done func(txid []byte, err error)) ([]byte, error) {
+	metrics := p.metrics[source]
+	txid, _, err := p.m.precheck(t)
+	if err != nil {
+		if errors.Is(err, ErrAlreadyExists) {
+			atomic.AddUint64(&metrics.duplicates, 1)
+		}
+		p.m.notifyRejected(t, txid, err)
+		return txid, err
+	}
+
+	var k txHash
+	copy(k[:], txid)
+
+	if err := p.enqueue(k, &verifyJob{t: t, txid: txid, srcPeer: srcPeer, source: source, queued: time.Now(), done: done}); err != nil {
+		if err != errStopped {
+			p.m.notifyRejected(t, txid, err)
+		}
+		return txid, err
+	}
+
+	return txid, nil
+}
+
+func (p *verificationPool) enqueue(k txHash, j *verifyJob) error {
+	metrics := p.metrics[j.source]
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.stop == nil {
+		return errStopped
+	}
+
+	if _, ok := p.inFlight[k]; ok {
+		atomic.AddUint64(&metrics.duplicates, 1)
+		return ErrAlreadyExists
+	}
+
+	select {
+	case p.queues[j.source] <- j:
+		p.inFlight[k] = struct{}{}
+		return nil
+	default:
+		atomic.AddUint64(&metrics.rejected, 1)
+		return fmt.Errorf("%w: %s", ErrQueueFull, j.source)
+	}
+}
+
+func (p *verificationPool) work(stop chan struct{}) {
+	defer p.wg.Done()
+	for {
+		// a flood of network txs does not delay the local ones more than
+		// the other way around, as select picks a ready queue at random
+		select {
+		case j := <-p.queues[Local]:
+			p.verify(j, stop)
+		case j := <-p.queues[Network]:
+			p.verify(j, stop)
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (p *verificationPool) verify(j *verifyJob, stop chan struct{}) {
+	metrics := p.metrics[j.source]
+	start := time.Now()
+	atomic.AddUint64(&metrics.waitNanos, uint64(start.Sub(j.queued)))
+
+	j.err = p.m.checkTx(j.t.tx)
+	atomic.AddUint64(&metrics.verifyNanos, uint64(time.Since(start)))
+	atomic.AddUint64(&metrics.verified, 1)
+
+	select {
+	case p.verified <- j:
+	case <-stop:
+		p.finish(j, errStopped)
+	}
+}
+
+// finish a job and notify its submitter
+func (p *verificationPool) finish(j *verifyJob, err error) {
+	var k txHash
+	copy(k[:], j.txid)
+
+	p.lock.Lock()
+	delete(p.inFlight, k)
+	p.lock.Unlock()
+
+	j.done(j.txid, err)
+}
+
+// stats returns the current VerificationStats
+func (p *verificationPool) stats() VerificationStats {
+	p.lock.Lock()
+	inFlight := len(p.inFlight)
+	p.lock.Unlock()
+
+	s := VerificationStats{
+		Workers:  p.workers,
+		InFlight: inFlight,
+		Queues:   make([]QueueStats, 0, len(p.sources)),
+	}
+
+	for _, source := range p.sources {
+		m := p.metrics[source]
+		q := QueueStats{
+			Source:     source,
+			Depth:      len(p.queues[source]),
+			Capacity:   cap(p.queues[source]),
+			Verified:   atomic.LoadUint64(&m.verified),
+			Rejected:   atomic.LoadUint64(&m.rejected),
+			Duplicates: atomic.LoadUint64(&m.duplicates),
+		}
+
+		// the received txs are waiting to be queued
+		if source == Network {
+			q.Depth += len(p.received)
+			q.Capacity += cap(p.received)
+		}
+
+		if q.Verified > 0 {
+			q.AvgWaitMs = float64(atomic.LoadUint64(&m.waitNanos)) / float64(q.Verified) / float64(time.Millisecond)
+			q.AvgVerifyMs = float64(atomic.LoadUint64(&m.verifyNanos)) / float64(q.Verified) / float64(time.Millisecond)
+		}
+
+		s.Queues = append(s.Queues, q)
+	}
+
+	return s
+}
+
+// commitVerified puts a verified tx into the pool. It runs on the main loop,
+// and checks the tx against the pool again, as it might have changed during
+// the verification. The peer which sent a tx rejected by the verifier is
+// reported to the Reputation
+func (m *Mempool) commitVerified(j *verifyJob) {
+	err := j.err
+	if err != nil {
+		err = fmt.Errorf("verification: %v", err)
+		m.invalidTx(j.srcPeer)
+	} else {
+		var replaced []txHash
+		if _, replaced, err = m.precheck(j.t); err == nil {
+			err = m.commit(j.t, j.txid, replaced)
+		}
+	}
+
+	if err != nil {
+		m.notifyRejected(j.t, j.txid, err)
+	}
+
+	m.verification.finish(j, err)
+}
+
+// processGetVerificationStatsRequest answers a topics.GetVerificationStats
+// request
+func (m *Mempool) processGetVerificationStatsRequest(r rpcbus.Request) (interface{}, error) {
+	return m.verification.stats(), nil
+}
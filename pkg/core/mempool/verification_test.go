@@ -0,0 +1,166 @@
+package mempool
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestVerificationDedupe tests that a tx is not queued twice while it is
+// being verified, and that a full queue rejects the txs of its source only
+func TestVerificationDedupe(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+
+	// workers are not started, so that the txs stay in their queues
+	p := newVerificationPool(m)
+	p.stop = make(chan struct{})
+	p.queues[Network] = make(chan *verifyJob, 1)
+	m.verification = p
+
+	done := func([]byte, error) {}
+	tx := TxDesc{tx: newFeeTx(10), received: time.Now(), size: 100}
+	_, err := p.submit(tx, Network, "", done)
+	assert.NoError(err)
+
+	_, err = p.submit(tx, Local, "", done)
+	assert.True(errors.Is(err, ErrAlreadyExists))
+
+	_, err = p.submit(TxDesc{tx: newFeeTx(10), received: time.Now(), size: 100}, Network, "", done)
+	assert.True(errors.Is(err, ErrQueueFull))
+
+	_, err = p.submit(TxDesc{tx: newFeeTx(10), received: time.Now(), size: 100}, Local, "", done)
+	assert.NoError(err)
+
+	s := p.stats()
+	assert.Equal(2, s.InFlight)
+	for _, q := range s.Queues {
+		assert.Equal(1, q.Depth)
+		switch q.Source {
+		case Network:
+			assert.Equal(uint64(1), q.Rejected)
+			assert.Equal(uint64(0), q.Duplicates)
+		case Local:
+			assert.Equal(uint64(0), q.Rejected)
+			assert.Equal(uint64(1), q.Duplicates)
+		}
+	}
+}
+
+// TestVerificationPipeline tests that the verified txs are handed back to be
+// pooled, and that their submitter is notified
+func TestVerificationPipeline(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+	p := newVerificationPool(m)
+	m.verification = p
+	p.start()
+	defer p.halt()
+
+	res := make(chan error, 1)
+	tx := newFeeTx(10)
+	txid, err := p.submit(TxDesc{tx: tx, received: time.Now(), size: 100}, Local, "", func(_ []byte, err error) {
+		res <- err
+	})
+	assert.NoError(err)
+
+	// act as the main loop
+	m.commitVerified(<-p.verified)
+	assert.NoError(<-res)
+	assert.True(m.verified.Contains(txid))
+
+	s := p.stats()
+	assert.Equal(0, s.InFlight)
+	for _, q := range s.Queues {
+		if q.Source == Local {
+			assert.Equal(uint64(1), q.Verified)
+		}
+	}
+
+	// a pooled tx is a duplicate as well
+	_, err = p.submit(TxDesc{tx: tx, received: time.Now(), size: 100}, Network, "", func([]byte, error) {})
+	assert.True(errors.Is(err, ErrAlreadyExists))
+}
+
+// TestVerificationReceive tests that the txs received from the network are
+// queued as is, until the main loop submits them for verification
+func TestVerificationReceive(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+	p := newVerificationPool(m)
+	p.received = make(chan *verifyJob, 1)
+	m.verification = p
+
+	tx := TxDesc{tx: newFeeTx(10), received: time.Now(), size: 100}
+	assert.True(errors.Is(p.receive(tx, ""), errStopped))
+
+	// workers are not started, so that the txs stay in their queues
+	p.stop = make(chan struct{})
+	assert.NoError(p.receive(tx, ""))
+	assert.True(errors.Is(p.receive(tx, ""), ErrQueueFull))
+
+	s := p.stats()
+	assert.Equal(0, s.InFlight)
+	for _, q := range s.Queues {
+		if q.Source == Network {
+			assert.Equal(1, q.Depth)
+			assert.Equal(uint64(1), q.Rejected)
+		}
+	}
+
+	// act as the main loop
+	j := <-p.received
+	m.verifyReceived(j.t, j.srcPeer)
+	assert.Equal(1, p.stats().InFlight)
+}
+
+type reputation struct {
+	misbehaved   map[string][]peer.Misbehaviour
+	disconnected []string
+}
+
+func (r *reputation) Misbehaved(addr string, m peer.Misbehaviour) bool {
+	r.misbehaved[addr] = append(r.misbehaved[addr], m)
+	return true
+}
+
+func (r *reputation) Disconnect(addr string) error {
+	r.disconnected = append(r.disconnected, addr)
+	return nil
+}
+
+// TestVerificationInvalidTx tests that the peer which sent a tx rejected by
+// the verifier is reported, and that the valid txs go unreported
+func TestVerificationInvalidTx(t *testing.T) {
+	assert := assert.New(t)
+	m, _ := newEvictionMempool()
+	p := newVerificationPool(m)
+	m.verification = p
+	r := &reputation{misbehaved: make(map[string][]peer.Misbehaviour)}
+	m.SetReputation(r)
+	p.start()
+	defer p.halt()
+
+	invalid := newFeeTx(10)
+	transactions.Invalidate(invalid)
+	assert.NoError(p.receive(TxDesc{tx: invalid, received: time.Now(), size: 100}, "10.0.0.1:7000"))
+	assert.NoError(p.receive(TxDesc{tx: newFeeTx(10), received: time.Now(), size: 100}, "10.0.0.2:7000"))
+
+	// act as the main loop
+	for i := 0; i < 2; i++ {
+		j := <-p.received
+		m.verifyReceived(j.t, j.srcPeer)
+	}
+
+	for i := 0; i < 2; i++ {
+		m.commitVerified(<-p.verified)
+	}
+
+	assert.Equal(map[string][]peer.Misbehaviour{"10.0.0.1:7000": {peer.InvalidTx}}, r.misbehaved)
+	assert.Equal([]string{"10.0.0.1:7000"}, r.disconnected)
+	assert.Equal(1, m.verified.Len())
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"crypto/rand"
 	"errors"
+	"io"
 	"os"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/signer"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/common"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
@@ -24,6 +28,10 @@ var (
 
 	errWalletNotLoaded     = errors.New("wallet is not loaded yet") //nolint
 	errWalletAlreadyLoaded = errors.New("wallet is already loaded") //nolint
+	errInvalidParams       = errors.New("invalid request parameters")
+	// errWithdrawalUnsupported is returned until Rusk exposes the stake and
+	// bid withdrawal contract calls
+	errWithdrawalUnsupported = errors.New("stake and bid withdrawal are not supported by rusk yet")
 )
 
 func (t *Transactor) handleCreateWallet(req *node.CreateRequest) (*node.LoadResponse, error) {
@@ -194,9 +202,9 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 		WithField("locktime", req.Locktime).
 		Tracef("Creating a stake tx")
 
-	blsKey := t.w.Keys().BLSPubKey
-	if blsKey == nil {
-		return nil, errWalletNotLoaded
+	blsKey, err := t.stakeKey()
+	if err != nil {
+		return nil, err
 	}
 
 	// TODO: use a parent context
@@ -204,7 +212,7 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 	// FIXME: 476 - we should calculate the expirationHeight somehow (by asking
 	// the chain for the last block through the RPC bus and calculating the
 	// height)
-	tx, err := t.proxy.Provider().NewStake(ctx, blsKey.Marshal(), req.Amount)
+	tx, err := t.proxy.Provider().NewStake(ctx, blsKey, req.Amount)
 	if err != nil {
 		log.
 			WithField("amount", req.Amount).
@@ -225,6 +233,31 @@ func (t *Transactor) handleSendStakeTx(req *node.StakeRequest) (*node.Transactio
 	return &node.TransactionResponse{Hash: hash}, nil
 }
 
+func (t *Transactor) handleWithdrawStakeTx(stake user.Stake) (*node.TransactionResponse, error) {
+	if t.w == nil {
+		return nil, errWalletNotLoaded
+	}
+
+	log.
+		WithField("amount", stake.Amount).
+		WithField("end_height", stake.EndHeight).
+		Tracef("Creating a withdraw stake tx")
+
+	// FIXME: the Rusk StakeService only creates new stakes. The withdrawal
+	// should be requested here once it exposes it
+	return nil, errWithdrawalUnsupported
+}
+
+func (t *Transactor) handleWithdrawBidTx() (*node.TransactionResponse, error) {
+	if t.w == nil {
+		return nil, errWalletNotLoaded
+	}
+
+	// FIXME: the Rusk BidService only creates and finds bids. The withdrawal
+	// should be requested here once it exposes it
+	return nil, errWithdrawalUnsupported
+}
+
 func (t *Transactor) handleSendStandardTx(req *node.TransferRequest) (*node.TransactionResponse, error) {
 	if t.w == nil {
 		return nil, errWalletNotLoaded
@@ -310,8 +343,14 @@ func (t *Transactor) publishTx(tx transactions.ContractCall) ([]byte, error) {
 		return nil, err
 	}
 
-	_, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 2*time.Second)
-	return hash, err
+	if _, err = t.rb.Call(topics.SendMempoolTx, rpcbus.NewRequest(tx), 2*time.Second); err != nil {
+		return hash, err
+	}
+
+	t.pendingLock.Lock()
+	t.pending[string(hash)] = struct{}{}
+	t.pendingLock.Unlock()
+	return hash, nil
 }
 
 func (t *Transactor) handleSendContract(c *node.CallContractRequest) (*node.TransactionResponse, error) {
@@ -344,10 +383,39 @@ func loadResponseFromPub(pubKey keys.PublicKey) *node.LoadResponse {
 	return &node.LoadResponse{Key: pk}
 }
 
+// stakeKey returns the BLS public key the stakes are made for. With a remote
+// signer, it is the key held by the signer process
+func (t *Transactor) stakeKey() ([]byte, error) {
+	if !signer.IsRemote() {
+		blsKey := t.w.Keys().BLSPubKey
+		if blsKey == nil {
+			return nil, errWalletNotLoaded
+		}
+		return blsKey.Marshal(), nil
+	}
+
+	s, err := signer.FromConfig(key.Keys{})
+	if err != nil {
+		return nil, err
+	}
+
+	if c, ok := s.(io.Closer); ok {
+		_ = c.Close()
+	}
+
+	return s.PublicKey(), nil
+}
+
 //nolint:unused
 func (t *Transactor) launchConsensus() {
 	log.Tracef("Launch consensus")
 	keys := t.w.Keys()
+	if signer.IsRemote() {
+		// the consensus keys of the wallet are not handed over, the
+		// consensus signs with the keys of the signer process
+		keys = key.Keys{}
+	}
+
 	go func() {
 		if err := t.setupConsensus(t.w.PublicKey, keys); err != nil {
 			log.WithError(err).Errorln("error setting up consensus")
@@ -2,11 +2,17 @@ package transactor
 
 import (
 	"context"
+	"encoding/hex"
+	"sync"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/key"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/keys"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/wallet"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
@@ -24,8 +30,10 @@ type Transactor struct { // TODO: rename
 	rb *rpcbus.RPCBus
 
 	// RPCBus channels
-	stakeChan <-chan rpcbus.Request
-	bidChan   <-chan rpcbus.Request
+	stakeChan         <-chan rpcbus.Request
+	bidChan           <-chan rpcbus.Request
+	withdrawStakeChan <-chan rpcbus.Request
+	withdrawBidChan   <-chan rpcbus.Request
 
 	// Passed to the consensus component startup
 	// c                 *chainsync.Counter
@@ -39,6 +47,11 @@ type Transactor struct { // TODO: rename
 	setupConsensus func(keys.PublicKey, key.Keys) error
 
 	w *wallet.Wallet
+
+	// pending are the txs sent to the mempool, and not yet accepted or
+	// evicted
+	pendingLock sync.Mutex
+	pending     map[string]struct{}
 }
 
 // New Instantiate a new Transactor struct.
@@ -49,15 +62,20 @@ func New(eb *eventbus.EventBus, rb *rpcbus.RPCBus, db database.DB, srv *grpc.Ser
 
 	stakeChan := make(chan rpcbus.Request, 1)
 	bidChan := make(chan rpcbus.Request, 1)
+	withdrawStakeChan := make(chan rpcbus.Request, 1)
+	withdrawBidChan := make(chan rpcbus.Request, 1)
 
 	t := &Transactor{
-		db:             db,
-		eb:             eb,
-		rb:             rb,
-		stakeChan:      stakeChan,
-		bidChan:        bidChan,
-		proxy:          proxy,
-		setupConsensus: setupConsensusFn,
+		db:                db,
+		eb:                eb,
+		rb:                rb,
+		stakeChan:         stakeChan,
+		bidChan:           bidChan,
+		withdrawStakeChan: withdrawStakeChan,
+		withdrawBidChan:   withdrawBidChan,
+		proxy:             proxy,
+		setupConsensus:    setupConsensusFn,
+		pending:           make(map[string]struct{}),
 	}
 
 	if srv != nil {
@@ -73,12 +91,23 @@ func New(eb *eventbus.EventBus, rb *rpcbus.RPCBus, db database.DB, srv *grpc.Ser
 		return nil, err
 	}
 
+	if err := rb.Register(topics.SendWithdrawStakeTx, withdrawStakeChan); err != nil {
+		return nil, err
+	}
+
+	if err := rb.Register(topics.SendWithdrawBidTx, withdrawBidChan); err != nil {
+		return nil, err
+	}
+
+	eb.Subscribe(topics.EvictedTx, eventbus.NewCallbackListener(t.onEvictedTx))
+	eb.Subscribe(topics.AcceptedBlock, eventbus.NewCallbackListener(t.onAcceptedBlock))
+
 	go t.Listen()
 	return t, nil
 }
 
 // Listen to the stake and bid channels and trigger a stake and bid transaction
-// requests
+// requests. The outcome is sent back to the caller
 func (t *Transactor) Listen() {
 	l := log.WithField("action", "listen")
 	for {
@@ -86,25 +115,85 @@ func (t *Transactor) Listen() {
 		case r := <-t.stakeChan:
 			req, ok := r.Params.(*node.StakeRequest)
 			if !ok {
+				r.RespChan <- rpcbus.NewResponse(nil, errInvalidParams)
 				continue
 			}
 
-			// QUESTION: should we return the hash of the transaction back to
-			// the client?
-			if _, err := t.Stake(context.Background(), req); err != nil {
+			resp, err := t.Stake(context.Background(), req)
+			if err != nil {
 				l.WithError(err).Error("error in creating a stake transaction")
 			}
+			r.RespChan <- rpcbus.NewResponse(resp, err)
 
 		case r := <-t.bidChan:
 			req, ok := r.Params.(*node.BidRequest)
 			if !ok {
+				r.RespChan <- rpcbus.NewResponse(nil, errInvalidParams)
 				continue
 			}
 
-			if _, err := t.Bid(context.Background(), req); err != nil {
+			resp, err := t.Bid(context.Background(), req)
+			if err != nil {
 				l.WithError(err).Error("error in creating a bid transaction")
 			}
+			r.RespChan <- rpcbus.NewResponse(resp, err)
+
+		case r := <-t.withdrawStakeChan:
+			stake, ok := r.Params.(user.Stake)
+			if !ok {
+				r.RespChan <- rpcbus.NewResponse(nil, errInvalidParams)
+				continue
+			}
+
+			resp, err := t.handleWithdrawStakeTx(stake)
+			r.RespChan <- rpcbus.NewResponse(resp, err)
+
+		case r := <-t.withdrawBidChan:
+			resp, err := t.handleWithdrawBidTx()
+			r.RespChan <- rpcbus.NewResponse(resp, err)
+		}
+	}
+}
+
+// onEvictedTx reports the eviction of the txs sent by the wallet, since they
+// will not make it into a block unless sent again
+func (t *Transactor) onEvictedTx(m message.Message) {
+	e, ok := m.Payload().(mempool.EvictedTx)
+	if !ok {
+		return
+	}
+
+	t.pendingLock.Lock()
+	_, own := t.pending[string(e.TxID)]
+	delete(t.pending, string(e.TxID))
+	t.pendingLock.Unlock()
+
+	if own {
+		log.WithField("txid", hex.EncodeToString(e.TxID)).
+			WithField("reason", e.Reason).
+			Warn("transaction evicted from the mempool")
+	}
+}
+
+// onAcceptedBlock stops tracking the txs which made it into a block
+func (t *Transactor) onAcceptedBlock(m message.Message) {
+	blk, ok := m.Payload().(block.Block)
+	if !ok {
+		return
+	}
+
+	t.pendingLock.Lock()
+	defer t.pendingLock.Unlock()
+	if len(t.pending) == 0 {
+		return
+	}
+
+	for _, tx := range blk.Txs {
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			continue
 		}
+		delete(t.pending, string(txid))
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/agreement"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/committee"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/header"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/user"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
@@ -34,18 +35,25 @@ func CheckBlockCertificate(provisioners user.Provisioners, blk block.Block) erro
 		return err
 	}
 
+	// The committees are most likely cached already, since the agreement
+	// extracted them during the round
+	setHash, err := provisioners.Hash()
+	if err != nil {
+		return err
+	}
+
 	// Now, check the certificate's correctness for both reduction steps
-	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, blk.Header.Hash); err != nil {
+	if err := checkBlockCertificateForStep(stepOneBatchedSig, blk.Header.Certificate.StepOneCommittee, blk.Header.Height, stepOne, provisioners, setHash, blk.Header.Hash); err != nil {
 		return err
 	}
 
-	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, blk.Header.Hash)
+	return checkBlockCertificateForStep(stepTwoBatchedSig, blk.Header.Certificate.StepTwoCommittee, blk.Header.Height, stepTwo, provisioners, setHash, blk.Header.Hash)
 }
 
-func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, blockHash []byte) error {
+func checkBlockCertificateForStep(batchedSig *bls.Signature, bitSet uint64, round uint64, step uint8, provisioners user.Provisioners, setHash []byte, blockHash []byte) error {
 	size := committeeSize(provisioners.SubsetSizeAt(round))
-	committee := provisioners.CreateVotingCommittee(round, step, size)
-	subcommittee := committee.IntersectCluster(bitSet)
+	votingCommittee := committee.SharedCache().Committee(provisioners, setHash, round, step, size)
+	subcommittee := votingCommittee.IntersectCluster(bitSet)
 	apk, err := agreement.ReconstructApk(subcommittee.Set)
 	if err != nil {
 		return err
@@ -66,36 +74,47 @@ func committeeSize(memberAmount int) int {
 // These are stateless and stateful checks
 // returns nil, if all checks pass
 func CheckBlockHeader(prevBlock block.Block, blk block.Block) error {
+	if err := CheckHeader(prevBlock.Header, blk.Header); err != nil {
+		return err
+	}
+
+	// Merkle tree check -- Check is here as the root is not calculated on decode
+	root, err := blk.CalculateRoot()
+	if err != nil {
+		return errors.New("could not calculate the merkle tree root for this header")
+	}
+
+	if !bytes.Equal(root, blk.Header.TxRoot) {
+		return errors.New("merkle root mismatch")
+	}
+
+	return nil
+}
+
+// CheckHeader checks whether a block header follows the previous one. Unlike
+// CheckBlockHeader, it does not need the block transactions, so that the light
+// nodes can check the headers they sync
+func CheckHeader(prevHeader *block.Header, h *block.Header) error {
 	// Version
-	if blk.Header.Version > 0 {
+	if h.Version > 0 {
 		return errors.New("unsupported block version")
 	}
 
 	// blk.Headerhash = prevHeaderHash
-	if !bytes.Equal(blk.Header.PrevBlockHash, prevBlock.Header.Hash) {
+	if !bytes.Equal(h.PrevBlockHash, prevHeader.Hash) {
 		return errors.New("Previous block hash does not equal the previous hash in the current block")
 	}
 
 	// blk.Headerheight = prevHeaderHeight +1
-	if blk.Header.Height != prevBlock.Header.Height+1 {
+	if h.Height != prevHeader.Height+1 {
 		return errors.New("current block height is not one plus the previous block height")
 	}
 
 	// blk.Timestamp > prevTimestamp
-	if blk.Header.Timestamp <= prevBlock.Header.Timestamp {
+	if h.Timestamp <= prevHeader.Timestamp {
 		return errors.New("current timestamp is less than the previous timestamp")
 	}
 
-	// Merkle tree check -- Check is here as the root is not calculated on decode
-	root, err := blk.CalculateRoot()
-	if err != nil {
-		return errors.New("could not calculate the merkle tree root for this header")
-	}
-
-	if !bytes.Equal(root, blk.Header.TxRoot) {
-		return errors.New("merkle root mismatch")
-	}
-
 	return nil
 }
 
@@ -1,14 +1,19 @@
 package gql
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/database/heavy"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-blockchain/pkg/gql/notifications"
 	"github.com/dusk-network/dusk-blockchain/pkg/gql/query"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -24,8 +29,13 @@ import (
 var log = logger.WithFields(logger.Fields{"prefix": "gql"})
 
 const (
-	endpointWS  = "/ws"
-	endpointGQL = "/graphql"
+	endpointWS        = "/ws"
+	endpointWSMempool = "/ws/mempool"
+	endpointGQL       = "/graphql"
+
+	// txTypesParam is the query parameter selecting the TxTypes of the
+	// mempool events, as a comma separated list (e.g. /ws/mempool?types=0,2)
+	txTypesParam = "types"
 )
 
 // Server defines the HTTP server of the GraphQL service node.
@@ -192,9 +202,51 @@ func (s *Server) EnableNotifications(serverMux *http.ServeMux) error {
 	middleware := tollbooth.LimitFuncHandler(s.lmt, wsHandler)
 	serverMux.Handle(endpointWS, middleware)
 
+	wsMempoolHandler := func(w http.ResponseWriter, r *http.Request) {
+
+		if !s.started {
+			return
+		}
+
+		filter, err := parseTxTypes(r.URL.Query().Get(txTypesParam))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			log.WithError(err).Error("Failed to set websocket upgrade")
+			return
+		}
+		s.pool.PushMempoolConn(conn, filter)
+	}
+
+	middleware = tollbooth.LimitFuncHandler(s.lmt, wsMempoolHandler)
+	serverMux.Handle(endpointWSMempool, middleware)
+
 	return nil
 }
 
+// parseTxTypes parses a comma separated list of TxTypes
+func parseTxTypes(param string) (mempool.TxEventFilter, error) {
+	filter := make(mempool.TxEventFilter, 0)
+	if param == "" {
+		return filter, nil
+	}
+
+	for _, v := range strings.Split(param, ",") {
+		t, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx type %q", v)
+		}
+
+		filter = append(filter, transactions.TxType(t))
+	}
+
+	return filter, nil
+}
+
 // Stop the server
 func (s *Server) Stop() error {
 
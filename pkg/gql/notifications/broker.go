@@ -7,6 +7,8 @@ import (
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	logger "github.com/sirupsen/logrus"
@@ -18,12 +20,15 @@ const (
 	writeDeadline = 3 * time.Second
 
 	maxTxsPerMsg = 15
+
+	mempoolEventsBuffer = 1000
 )
 
 var log = logger.WithField("process", "broker")
 
 // Broker is a pub/sub broker that keeps updated all subscribers (websocket
-// connections) with latest block accepted published by node layer
+// connections) with latest block accepted published by node layer, or with
+// the mempool events they subscribed to
 //
 // IMPL Notes:
 // Broker is implemented in a non-blocking manner. That means it should not be
@@ -45,6 +50,8 @@ type Broker struct {
 	eventBus          eventbus.Broker
 	acceptedBlockChan chan block.Block
 	acceptedBlockID   uint32
+	mempoolEventChan  chan message.Message
+	mempoolEventID    uint32
 }
 
 // NewBroker creates a new Broker instance
@@ -54,6 +61,8 @@ func NewBroker(id uint, eventBus eventbus.Broker, maxClientsCount uint, connChan
 	b.eventBus = eventBus
 	b.ConnectionChan = connChan
 	b.acceptedBlockChan, b.acceptedBlockID = consensus.InitAcceptedBlockUpdate(eventBus)
+	b.mempoolEventChan = make(chan message.Message, mempoolEventsBuffer)
+	b.mempoolEventID = eventBus.Subscribe(topics.MempoolEvent, eventbus.NewSafeChanListener(b.mempoolEventChan))
 	b.clients = list.New()
 	b.maxClientsCount = maxClientsCount
 	b.id = id
@@ -74,6 +83,7 @@ func (b *Broker) Run() {
 
 		// Unsubscribe from all eventBus events
 		b.eventBus.Unsubscribe(topics.AcceptedBlock, b.acceptedBlockID)
+		b.eventBus.Unsubscribe(topics.MempoolEvent, b.mempoolEventID)
 
 		// Terminate all clients goroutines
 		for e := b.clients.Front(); e != nil; e = e.Next() {
@@ -99,6 +109,9 @@ func (b *Broker) Run() {
 		// new accepted block from node
 		case blk := <-b.acceptedBlockChan:
 			b.handleBlock(blk)
+		// new mempool event from node
+		case m := <-b.mempoolEventChan:
+			b.handleMempoolEvent(m.Payload().(mempool.TxEvent))
 		case <-time.After(30 * time.Second):
 			b.handleIdle()
 		}
@@ -125,6 +138,33 @@ func (b *Broker) handleBlock(blk block.Block) {
 	b.broadcastMessage(msg)
 }
 
+// handleMempoolEvent handles the topics.MempoolEvent event emitted from node
+// layer. It packs a json from the event and sends it to the clients
+// subscribed to its TxType
+func (b *Broker) handleMempoolEvent(e mempool.TxEvent) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("handleMempoolEvent recovered from err: %v", r)
+		}
+	}()
+
+	b.reap()
+
+	msg, err := MarshalMempoolEventMsg(e)
+	if err != nil {
+		log.Errorf("encoding err: %v", err)
+		return
+	}
+
+	for el := b.clients.Front(); el != nil; el = el.Next() {
+		c := el.Value.(*wsClient)
+		if c.mempoolEvents && c.filter.Match(e) {
+			c.send([]byte(msg))
+		}
+	}
+}
+
 // handleConn handles a new websocket conn pushed from webserver layer It stores
 // the conn to list of active clients
 func (b *Broker) handleConn(conn wsConn) {
@@ -155,6 +195,11 @@ func (b *Broker) handleConn(conn wsConn) {
 		id:      conn.RemoteAddr().String(),
 	}
 
+	if mc, ok := conn.(*mempoolConn); ok {
+		c.mempoolEvents = true
+		c.filter = mc.filter
+	}
+
 	_ = b.clients.PushBack(c)
 
 	// Start a writer-goroutine dedicated for websocket conn. All messages to a
@@ -185,7 +230,9 @@ func (b *Broker) broadcastMessage(data string) {
 
 	for e := b.clients.Front(); e != nil; e = e.Next() {
 		c := e.Value.(*wsClient)
-		c.msgChan <- []byte(data)
+		if !c.mempoolEvents {
+			c.msgChan <- []byte(data)
+		}
 	}
 }
 
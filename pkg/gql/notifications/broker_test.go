@@ -4,6 +4,9 @@ import (
 	"container/list"
 	"strconv"
 	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 )
 
 func TestReapClients(t *testing.T) {
@@ -40,3 +43,32 @@ func TestReapClients(t *testing.T) {
 		t.Fatalf("Not all closed")
 	}
 }
+
+func TestMempoolEventFilter(t *testing.T) {
+
+	b := Broker{}
+	b.clients = list.New()
+
+	blocks := &wsClient{msgChan: make(chan []byte, 10)}
+	stakes := &wsClient{msgChan: make(chan []byte, 10), mempoolEvents: true, filter: mempool.TxEventFilter{transactions.Stake}}
+	all := &wsClient{msgChan: make(chan []byte, 10), mempoolEvents: true}
+	for _, c := range []*wsClient{blocks, stakes, all} {
+		b.clients.PushBack(c)
+	}
+
+	b.handleMempoolEvent(mempool.TxEvent{Type: mempool.Added, TxID: []byte{1}, TxType: transactions.Stake})
+	b.handleMempoolEvent(mempool.TxEvent{Type: mempool.Added, TxID: []byte{2}, TxType: transactions.Tx})
+	b.broadcastMessage("block")
+
+	if len(blocks.msgChan) != 1 {
+		t.Fatalf("expected only the block message, got %d messages", len(blocks.msgChan))
+	}
+
+	if len(stakes.msgChan) != 1 {
+		t.Fatalf("expected only the stake event, got %d messages", len(stakes.msgChan))
+	}
+
+	if len(all.msgChan) != 2 {
+		t.Fatalf("expected all the mempool events, got %d messages", len(all.msgChan))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/gorilla/websocket"
 )
 
@@ -14,6 +15,11 @@ type wsClient struct {
 	msgChan chan []byte
 	id      string
 
+	// mempoolEvents clients receive the mempool events selected by filter
+	// instead of the accepted blocks
+	mempoolEvents bool
+	filter        mempool.TxEventFilter
+
 	closed int32
 }
 
@@ -55,6 +61,16 @@ func (c *wsClient) writeLoop() {
 	atomic.AddInt32(&c.closed, 1)
 }
 
+// send a message without blocking. Messages are dropped if the client does
+// not keep up
+func (c *wsClient) send(msg []byte) {
+	select {
+	case c.msgChan <- msg:
+	default:
+		log.Tracef("client %s is too slow, message dropped", c.id)
+	}
+}
+
 func (c *wsClient) readLoop() {
 	for {
 		if _, _, err := c.conn.NextReader(); err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 )
 
 // BlockMsg represents the data need by Explorer UI on each new block accepted
@@ -53,3 +54,34 @@ func MarshalBlockMsg(blk block.Block) (string, error) {
 
 	return string(msg), nil
 }
+
+// MempoolEventMsg represents a tx entering or leaving the mempool, or being
+// rejected by it
+type MempoolEventMsg struct {
+	Event  string
+	TxID   string
+	TxType uint32
+	Fee    uint64
+	Size   uint
+	Reason string
+}
+
+// MarshalMempoolEventMsg builds the JSON of a mempool.TxEvent
+func MarshalMempoolEventMsg(e mempool.TxEvent) (string, error) {
+
+	p := MempoolEventMsg{
+		Event:  string(e.Type),
+		TxID:   hex.EncodeToString(e.TxID),
+		TxType: uint32(e.TxType),
+		Fee:    e.Fee,
+		Size:   e.Size,
+		Reason: e.Reason,
+	}
+
+	msg, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	return string(msg), nil
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
 	"github.com/gorilla/websocket"
 )
@@ -20,6 +21,13 @@ type wsConn interface {
 	Close() error
 }
 
+// mempoolConn is a websocket connection subscribed to the mempool events
+// selected by filter
+type mempoolConn struct {
+	wsConn
+	filter mempool.TxEventFilter
+}
+
 // BrokerPool is a set of broker workers to provide a simple load balancing.
 // Running multiple broker workers also could provide failover
 type BrokerPool struct {
@@ -69,6 +77,21 @@ func (bp *BrokerPool) PushConn(conn *websocket.Conn) {
 	}
 }
 
+// PushMempoolConn pushes a websocket connection subscribed to the mempool
+// events of the TxTypes in filter. An empty filter subscribes to all of them
+func (bp *BrokerPool) PushMempoolConn(conn *websocket.Conn, filter mempool.TxEventFilter) {
+
+	if conn == nil {
+		return
+	}
+
+	select {
+	case bp.ConnectionsChan <- &mempoolConn{wsConn: conn, filter: filter}:
+	default:
+		log.Errorf("Queue is full. Discarding connection from %s", conn.RemoteAddr().String())
+	}
+}
+
 // Close the BrokerPool by closing the underlying connection channel
 func (bp *BrokerPool) Close() {
 
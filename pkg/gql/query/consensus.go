@@ -0,0 +1,181 @@
+package query
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+// File purpose is to define all arguments and resolvers relevant to "consensus" query only
+
+// ConsensusStatus is the graphql object representing the state of the
+// consensus loop
+var ConsensusStatus = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "ConsensusStatus",
+		Fields: graphql.Fields{
+			"round": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"phase": &graphql.Field{
+				Type: graphql.String,
+			},
+			"timeoutms": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"committeemember": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+			"reductionvotes": &graphql.Field{
+				Type: graphql.NewList(HashVotes),
+			},
+			"agreementquorum": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"agreementvotes": &graphql.Field{
+				Type: graphql.NewList(StepVotes),
+			},
+			"lastcertificate": &graphql.Field{
+				Type: Certificate,
+			},
+		},
+	},
+)
+
+// HashVotes is the graphql object representing the votes collected for a
+// block hash
+var HashVotes = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "HashVotes",
+		Fields: graphql.Fields{
+			"hash": &graphql.Field{
+				Type: graphql.String,
+			},
+			"votes": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	},
+)
+
+// StepVotes is the graphql object representing the votes collected for a step
+var StepVotes = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "StepVotes",
+		Fields: graphql.Fields{
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"votes": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	},
+)
+
+// Certificate is the graphql object representing a block certificate
+var Certificate = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Certificate",
+		Fields: graphql.Fields{
+			"step": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"steponebatchedsig": &graphql.Field{
+				Type: Hex,
+			},
+			"steptwobatchedsig": &graphql.Field{
+				Type: Hex,
+			},
+			"steponecommittee": &graphql.Field{
+				Type: graphql.String,
+			},
+			"steptwocommittee": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	},
+)
+
+type consensusStatus struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+func (c consensusStatus) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type:    ConsensusStatus,
+		Resolve: c.resolve,
+	}
+}
+
+func (c consensusStatus) resolve(p graphql.ResolveParams) (interface{}, error) {
+	timeout := time.Duration(config.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := c.rpcBus.Call(topics.GetConsensusStatus, rpcbus.EmptyRequest(), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := resp.(consensus.Status)
+	if !ok {
+		return nil, errors.New("unexpected consensus status response")
+	}
+
+	return newQueryConsensusStatus(s), nil
+}
+
+func newQueryConsensusStatus(s consensus.Status) map[string]interface{} {
+	reductionVotes := make([]map[string]interface{}, 0, len(s.ReductionVotes))
+	for hash, votes := range s.ReductionVotes {
+		reductionVotes = append(reductionVotes, map[string]interface{}{
+			"hash":  hash,
+			"votes": votes,
+		})
+	}
+
+	steps := make([]int, 0, len(s.Agreement.Votes))
+	for step := range s.Agreement.Votes {
+		steps = append(steps, int(step))
+	}
+	sort.Ints(steps)
+
+	agreementVotes := make([]map[string]interface{}, 0, len(steps))
+	for _, step := range steps {
+		agreementVotes = append(agreementVotes, map[string]interface{}{
+			"step":  step,
+			"votes": s.Agreement.Votes[uint8(step)],
+		})
+	}
+
+	q := map[string]interface{}{
+		"round":           int64(s.Round),
+		"step":            int(s.Step),
+		"phase":           s.Phase,
+		"timeoutms":       s.Timeout.Milliseconds(),
+		"committeemember": s.CommitteeMember,
+		"reductionvotes":  reductionVotes,
+		"agreementquorum": s.Agreement.Quorum,
+		"agreementvotes":  agreementVotes,
+	}
+
+	if s.LastCertificate != nil {
+		q["lastcertificate"] = map[string]interface{}{
+			"step":              int(s.LastCertificate.Step),
+			"steponebatchedsig": s.LastCertificate.StepOneBatchedSig,
+			"steptwobatchedsig": s.LastCertificate.StepTwoBatchedSig,
+			"steponecommittee":  strconv.FormatUint(s.LastCertificate.StepOneCommittee, 2),
+			"steptwocommittee":  strconv.FormatUint(s.LastCertificate.StepTwoCommittee, 2),
+		}
+	}
+
+	return q
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	mpool "github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+// File purpose is to define all arguments and resolvers relevant to "fee" query only
+
+const targetBlocksArg = "targetblocks"
+
+// FeeEstimate is the graphql object representing the fee a tx should pay to
+// be included within a target amount of blocks. The fee is a decimal string,
+// as it does not fit the 32-bit graphql.Int
+var FeeEstimate = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "FeeEstimate",
+		Fields: graphql.Fields{
+			"targetblocks": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"fee": &graphql.Field{
+				Type: graphql.String,
+			},
+		},
+	},
+)
+
+type feeEstimate struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+func (f feeEstimate) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: FeeEstimate,
+		Args: graphql.FieldConfigArgument{
+			targetBlocksArg: &graphql.ArgumentConfig{
+				Type:         graphql.Int,
+				DefaultValue: 1,
+			},
+		},
+		Resolve: f.resolve,
+	}
+}
+
+func (f feeEstimate) resolve(p graphql.ResolveParams) (interface{}, error) {
+	target, ok := p.Args[targetBlocksArg].(int)
+	if !ok || target < 1 {
+		return nil, mpool.ErrInvalidTarget
+	}
+
+	timeout := time.Duration(config.Get().Timeout.TimeoutGetMempoolTXs) * time.Second
+	resp, err := f.rpcBus.Call(topics.EstimateFee, rpcbus.NewRequest(uint32(target)), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	e, ok := resp.(mpool.FeeEstimate)
+	if !ok {
+		return nil, errors.New("unexpected fee estimate response")
+	}
+
+	return map[string]interface{}{
+		"targetblocks": int64(e.TargetBlocks),
+		"fee":          strconv.FormatUint(e.Fee, 10),
+	}, nil
+}
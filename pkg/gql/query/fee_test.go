@@ -0,0 +1,54 @@
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	mpool "github.com/dusk-network/dusk-blockchain/pkg/core/mempool"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestFeeEstimate tests that fees beyond 32 bits are returned unaltered
+func TestFeeEstimate(t *testing.T) {
+	assert := assert.New(t)
+
+	rb := rpcbus.New()
+	reqChan := make(chan rpcbus.Request, 1)
+	assert.NoError(rb.Register(topics.EstimateFee, reqChan))
+	go func() {
+		r := <-reqChan
+		r.RespChan <- rpcbus.NewResponse(mpool.FeeEstimate{TargetBlocks: 1, Fee: 1 << 40}, nil)
+	}()
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: NewRoot(rb).Query})
+	assert.NoError(err)
+
+	query := `
+		{
+		  fee(targetblocks: 1) {
+			targetblocks
+			fee
+		  }
+		}
+		`
+	response := `
+		{
+		  "data":{
+			"fee":{
+			  "targetblocks":1,
+			  "fee":"1099511627776"
+			}
+		  }
+		}
+	`
+
+	result, err := json.Marshal(execute(query, schema, db))
+	assert.NoError(err)
+
+	equal, err := assertJSONs(result, []byte(response))
+	assert.NoError(err)
+	assert.True(equal, string(result))
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/consensus/participation"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	"github.com/graphql-go/graphql"
+)
+
+// File purpose is to define all arguments and resolvers relevant to "participation" query only
+
+// Participation is the graphql object representing the votes of a
+// provisioner in the certified reduction committees
+var Participation = graphql.NewObject(
+	graphql.ObjectConfig{
+		Name: "Participation",
+		Fields: graphql.Fields{
+			"blskey": &graphql.Field{
+				Type: Hex,
+			},
+			"selected": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"voted": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"missed": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"lastselected": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"lastvoted": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	},
+)
+
+type provisionerParticipation struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+func (c provisionerParticipation) getQuery() *graphql.Field {
+	return &graphql.Field{
+		Type: graphql.NewList(Participation),
+		Args: graphql.FieldConfigArgument{
+			"blskey": &graphql.ArgumentConfig{
+				Type: graphql.String,
+			},
+		},
+		Resolve: c.resolve,
+	}
+}
+
+func (c provisionerParticipation) resolve(p graphql.ResolveParams) (interface{}, error) {
+	var pubKeyBLS []byte
+	if keyStr, ok := p.Args["blskey"].(string); ok && keyStr != "" {
+		var err error
+		pubKeyBLS, err = hex.DecodeString(keyStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	timeout := time.Duration(config.Get().Timeout.TimeoutGetRoundResults) * time.Second
+	resp, err := c.rpcBus.Call(topics.GetParticipation, rpcbus.NewRequest(pubKeyBLS), timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, ok := resp.([]participation.Stats)
+	if !ok {
+		return nil, errors.New("unexpected participation response")
+	}
+
+	q := make([]map[string]interface{}, 0, len(stats))
+	for _, s := range stats {
+		q = append(q, map[string]interface{}{
+			"blskey":       s.PublicKeyBLS,
+			"selected":     int64(s.Selected),
+			"voted":        int64(s.Voted),
+			"missed":       int64(s.Missed),
+			"lastselected": int64(s.LastSelected),
+			"lastvoted":    int64(s.LastVoted),
+		})
+	}
+
+	return q, nil
+}
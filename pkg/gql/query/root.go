@@ -10,19 +10,25 @@ type Root struct {
 	Query *graphql.Object
 }
 
-// NewRoot returns a Root with blocks, transactions and mempool setup
+// NewRoot returns a Root with blocks, transactions, mempool, consensus, participation and fee setup
 func NewRoot(rpcBus *rpcbus.RPCBus) *Root {
 
 	m := mempool{rpcBus: rpcBus}
+	c := consensusStatus{rpcBus: rpcBus}
+	pp := provisionerParticipation{rpcBus: rpcBus}
+	f := feeEstimate{rpcBus: rpcBus}
 
 	root := Root{
 		Query: graphql.NewObject(
 			graphql.ObjectConfig{
 				Name: "Query",
 				Fields: graphql.Fields{
-					"blocks":       blocks{}.getQuery(),
-					"transactions": transactions{}.getQuery(),
-					"mempool":      m.getQuery(),
+					"blocks":        blocks{}.getQuery(),
+					"transactions":  transactions{}.getQuery(),
+					"mempool":       m.getQuery(),
+					"consensus":     c.getQuery(),
+					"participation": pp.getQuery(),
+					"fee":           f.getQuery(),
 				},
 			},
 		),
@@ -23,6 +23,9 @@ type Peer struct {
 	w *Writer
 	r *Reader
 
+	// router is the routing table of the launched peer
+	router *RoutingTable
+
 	raptorCodeEnabled bool
 }
 
@@ -37,6 +40,7 @@ func (p *Peer) Launch(addr string, bootstrapAddrs []string, beta uint8) {
 	// Instantiate Kadcast Router
 	router := MakeRoutingTable(addr)
 	peerInfo := router.LpeerInfo
+	p.router = &router
 
 	if beta > 0 {
 		router.beta = beta
@@ -70,6 +74,12 @@ func (p *Peer) Launch(addr string, bootstrapAddrs []string, beta uint8) {
 	go JoinNetwork(&router, bootstrapAddrs)
 }
 
+// RoutingTable returns the routing table of the peer, or nil if it was not
+// launched
+func (p *Peer) RoutingTable() *RoutingTable {
+	return p.router
+}
+
 // Close terminates peer service
 func (p *Peer) Close() {
 
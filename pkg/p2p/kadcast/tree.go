@@ -56,6 +56,31 @@ func (tree *Tree) getTotalPeers() uint64 {
 	return count
 }
 
+// BucketInfo lists the peers of a bucket of the routing tree
+type BucketInfo struct {
+	Index uint8
+	Peers []encoding.PeerInfo
+}
+
+// Buckets returns the non-empty buckets of the routing table
+func (rt *RoutingTable) Buckets() []BucketInfo {
+	rt.tree.mu.RLock()
+	defer rt.tree.mu.RUnlock()
+
+	buckets := make([]BucketInfo, 0)
+	for _, b := range rt.tree.buckets {
+		if len(b.entries) == 0 {
+			continue
+		}
+
+		peers := make([]encoding.PeerInfo, len(b.entries))
+		copy(peers, b.entries)
+		buckets = append(buckets, BucketInfo{Index: b.idLength, Peers: peers})
+	}
+
+	return buckets
+}
+
 func (tree *Tree) trace(myPeer encoding.PeerInfo) string {
 
 	logMsg := fmt.Sprintf("this_peer: %s, bucket peers num %d\n", myPeer.String(), tree.getTotalPeers())
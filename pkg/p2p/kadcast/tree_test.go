@@ -2,6 +2,7 @@ package kadcast
 
 import (
 	"encoding/binary"
+	"fmt"
 	"testing"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/encoding"
@@ -36,3 +37,31 @@ func TestTree(t *testing.T) {
 
 	t.Log(tree.trace(myPeer))
 }
+
+func TestRoutingTableBuckets(t *testing.T) {
+
+	rt := MakeRoutingTable("127.0.0.1:7000")
+	if len(rt.Buckets()) != 0 {
+		t.Fatal("expected no buckets on an empty routing table")
+	}
+
+	for port := 7001; port <= 7010; port++ {
+		p, err := encoding.MakePeerFromAddr(fmt.Sprintf("127.0.0.1:%d", port))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rt.tree.addPeer(rt.LpeerInfo, p)
+	}
+
+	var total int
+	for _, b := range rt.Buckets() {
+		if len(b.Peers) == 0 {
+			t.Fatalf("bucket %d is empty", b.Index)
+		}
+		total += len(b.Peers)
+	}
+
+	if uint64(total) != rt.tree.getTotalPeers() {
+		t.Fatalf("expected %d peers, got %d", rt.tree.getTotalPeers(), total)
+	}
+}
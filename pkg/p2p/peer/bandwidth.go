@@ -0,0 +1,322 @@
+package peer
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// Traffic counts the messages of a topic, and their size on the wire
+type Traffic struct {
+	Messages uint64 `json:"messages"`
+	Bytes    uint64 `json:"bytes"`
+}
+
+func (t *Traffic) add(o Traffic) {
+	t.Messages += o.Messages
+	t.Bytes += o.Bytes
+}
+
+// TopicTraffic is the traffic of a topic in both directions
+type TopicTraffic struct {
+	Sent     Traffic `json:"sent"`
+	Received Traffic `json:"received"`
+}
+
+// PeerBandwidth is the traffic exchanged with a connected peer
+type PeerBandwidth struct {
+	Sent     Traffic `json:"sent"`
+	Received Traffic `json:"received"`
+	// Throttled is the amount of times the reads from the peer were delayed
+	// for exceeding the peer rate limit
+	Throttled uint64 `json:"throttled"`
+	// Dropped is the amount of messages from the peer dropped for exceeding
+	// the rate limit of their topic
+	Dropped uint64 `json:"dropped"`
+	// Topics is the traffic of each topic, by name
+	Topics map[string]TopicTraffic `json:"topics"`
+}
+
+// BandwidthStats is the traffic of the node since it started, along with the
+// traffic of the connected peers
+type BandwidthStats struct {
+	Sent      Traffic                  `json:"sent"`
+	Received  Traffic                  `json:"received"`
+	Throttled uint64                   `json:"throttled"`
+	Dropped   uint64                   `json:"dropped"`
+	Topics    map[string]TopicTraffic  `json:"topics"`
+	Peers     map[string]PeerBandwidth `json:"peers"`
+}
+
+// meter counts the traffic by topic. It is safe for concurrent use
+type meter struct {
+	sent      [256]Traffic
+	received  [256]Traffic
+	throttled uint64
+	dropped   uint64
+
+	// conn, height, latency and pingSent make the PeerStatus
+	conn     net.Conn
+	height   uint64
+	latency  int64
+	pingSent int64
+}
+
+func countTraffic(t *Traffic, n int) {
+	atomic.AddUint64(&t.Messages, 1)
+	atomic.AddUint64(&t.Bytes, uint64(n))
+}
+
+func loadTraffic(t *Traffic) Traffic {
+	return Traffic{
+		Messages: atomic.LoadUint64(&t.Messages),
+		Bytes:    atomic.LoadUint64(&t.Bytes),
+	}
+}
+
+// stats returns the traffic of the meter, skipping the idle topics
+func (m *meter) stats() PeerBandwidth {
+	s := PeerBandwidth{
+		Throttled: atomic.LoadUint64(&m.throttled),
+		Dropped:   atomic.LoadUint64(&m.dropped),
+		Topics:    make(map[string]TopicTraffic),
+	}
+
+	for i := range m.sent {
+		t := TopicTraffic{Sent: loadTraffic(&m.sent[i]), Received: loadTraffic(&m.received[i])}
+		if t.Sent.Messages == 0 && t.Received.Messages == 0 {
+			continue
+		}
+
+		s.Sent.add(t.Sent)
+		s.Received.add(t.Received)
+		s.Topics[topics.Topic(i).String()] = t
+	}
+
+	return s
+}
+
+// bandwidth meters the traffic of the node, and of each connected peer by
+// address
+var bandwidth = struct {
+	total meter
+	lock  sync.Mutex
+	peers map[string]*meter
+}{peers: make(map[string]*meter)}
+
+// meterPeer starts metering the traffic of the peer connected at addr
+// through conn
+func meterPeer(addr string, conn net.Conn) *meter {
+	m := &meter{conn: conn}
+	bandwidth.lock.Lock()
+	bandwidth.peers[addr] = m
+	bandwidth.lock.Unlock()
+	return m
+}
+
+// unmeterPeer forgets the traffic of a disconnected peer
+func unmeterPeer(addr string, m *meter) {
+	bandwidth.lock.Lock()
+	defer bandwidth.lock.Unlock()
+	if bandwidth.peers[addr] == m {
+		delete(bandwidth.peers, addr)
+	}
+}
+
+// GetBandwidthStats returns the bandwidth statistics of the node
+func GetBandwidthStats() BandwidthStats {
+	total := bandwidth.total.stats()
+	s := BandwidthStats{
+		Sent:      total.Sent,
+		Received:  total.Received,
+		Throttled: total.Throttled,
+		Dropped:   total.Dropped,
+		Topics:    total.Topics,
+		Peers:     make(map[string]PeerBandwidth),
+	}
+
+	bandwidth.lock.Lock()
+	defer bandwidth.lock.Unlock()
+	for addr, m := range bandwidth.peers {
+		s.Peers[addr] = m.stats()
+	}
+
+	return s
+}
+
+// topicOf returns the topic of a message
+func topicOf(message []byte) topics.Topic {
+	if len(message) == 0 {
+		return topics.Unknown
+	}
+
+	return topics.Topic(message[0])
+}
+
+// countSent counts a message of the given topic, of n bytes on the wire,
+// sent to the peer
+func (c *Connection) countSent(topic topics.Topic, n int) {
+	countTraffic(&bandwidth.total.sent[topic], n)
+	if c.meter != nil {
+		countTraffic(&c.meter.sent[topic], n)
+	}
+}
+
+// countReceived counts a message of the given topic, of n bytes on the wire,
+// received from the peer
+func (c *Connection) countReceived(topic topics.Topic, n int) {
+	countTraffic(&bandwidth.total.received[topic], n)
+	if c.meter != nil {
+		countTraffic(&c.meter.received[topic], n)
+	}
+}
+
+func (c *Connection) countThrottled() {
+	atomic.AddUint64(&bandwidth.total.throttled, 1)
+	if c.meter != nil {
+		atomic.AddUint64(&c.meter.throttled, 1)
+	}
+}
+
+func (c *Connection) countDropped() {
+	atomic.AddUint64(&bandwidth.total.dropped, 1)
+	if c.meter != nil {
+		atomic.AddUint64(&c.meter.dropped, 1)
+	}
+}
+
+// RateLimits of the traffic received from each peer. Zero rates are unlimited
+type RateLimits struct {
+	// PeerBytes is the amount of bytes per second read from a peer. The
+	// reads beyond it are delayed, throttling the peer
+	PeerBytes float64
+	// Topics is the amount of messages per second of a topic accepted from a
+	// peer. The messages beyond it are dropped, and reported as
+	// RateLimited misbehaviours
+	Topics map[topics.Topic]float64
+}
+
+// rateLimits are the limits of the Readers spawned from now on
+var rateLimits RateLimits
+
+// SetRateLimits sets the limits of the traffic received from the peers
+// connected from now on
+func SetRateLimits(limits RateLimits) {
+	rateLimits = limits
+}
+
+// bucket is a token bucket, refilled at rate tokens per second up to burst
+// tokens
+type bucket struct {
+	rate    float64
+	burst   float64
+	tokens  float64
+	updated time.Time
+}
+
+// newBucket returns a full bucket, allowing a second of traffic at once. It
+// returns nil for the zero rates, which are unlimited
+func newBucket(rate float64, now time.Time) *bucket {
+	if rate <= 0 {
+		return nil
+	}
+
+	burst := rate
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &bucket{rate: rate, burst: burst, tokens: burst, updated: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.updated).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.updated = now
+}
+
+// take n tokens, going in debt if needed. It returns how long to wait for the
+// debt to be repaid
+func (b *bucket) take(n float64, now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens -= n
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// allow takes a token if there is one left
+func (b *bucket) allow(now time.Time) bool {
+	b.refill(now)
+	if b.tokens < 1 {
+		return false
+	}
+
+	b.tokens--
+	return true
+}
+
+// limiter enforces the RateLimits on the traffic of a peer. It is used by
+// the readLoop only. A nil limiter is unlimited
+type limiter struct {
+	peer   *bucket
+	topics map[topics.Topic]*bucket
+}
+
+func newLimiter(limits RateLimits, now time.Time) *limiter {
+	l := &limiter{
+		peer:   newBucket(limits.PeerBytes, now),
+		topics: make(map[topics.Topic]*bucket),
+	}
+
+	for topic, rate := range limits.Topics {
+		if b := newBucket(rate, now); b != nil {
+			l.topics[topic] = b
+		}
+	}
+
+	return l
+}
+
+// throttle waits until the peer is back within its limit, after n bytes were
+// read from it. It returns false if the context was canceled meanwhile
+func (l *limiter) throttle(ctx context.Context, c *Connection, n int) bool {
+	if l == nil || l.peer == nil {
+		return true
+	}
+
+	delay := l.peer.take(float64(n), time.Now())
+	if delay == 0 {
+		return true
+	}
+
+	c.countThrottled()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// allow tells if a message of the given topic is within the limit of its
+// topic
+func (l *limiter) allow(topic topics.Topic) bool {
+	if l == nil {
+		return true
+	}
+
+	b, ok := l.topics[topic]
+	return !ok || b.allow(time.Now())
+}
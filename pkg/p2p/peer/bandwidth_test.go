@@ -0,0 +1,163 @@
+package peer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBucket ensures the token buckets refill at their rate, up to a second
+// of traffic
+func TestBucket(t *testing.T) {
+	assert := require.New(t)
+	now := time.Now()
+	assert.Nil(newBucket(0, now))
+
+	b := newBucket(10, now)
+	assert.Equal(time.Duration(0), b.take(10, now))
+	assert.Equal(500*time.Millisecond, b.take(5, now))
+	// the debt is repaid before the bucket refills
+	assert.Equal(time.Duration(0), b.take(10, now.Add(1500*time.Millisecond)))
+	assert.Equal(time.Second, b.take(10, now.Add(1500*time.Millisecond)))
+
+	b = newBucket(1, now)
+	assert.True(b.allow(now))
+	assert.False(b.allow(now.Add(500 * time.Millisecond)))
+	assert.True(b.allow(now.Add(time.Second)))
+	// the unused tokens do not pile up beyond the burst
+	assert.True(b.allow(now.Add(time.Minute)))
+	assert.False(b.allow(now.Add(time.Minute)))
+}
+
+// TestBandwidthStats ensures the traffic is counted by topic, and by peer
+// until it disconnects
+func TestBandwidthStats(t *testing.T) {
+	assert := require.New(t)
+	addr := "10.0.0.1:7000"
+	c := &Connection{meter: meterPeer(addr, nil)}
+
+	before := GetBandwidthStats()
+	c.countSent(topics.Tx, 100)
+	c.countReceived(topics.GetData, 40)
+	c.countReceived(topics.GetData, 60)
+	c.countDropped()
+
+	stats := GetBandwidthStats()
+	assert.Equal(before.Sent.Bytes+100, stats.Sent.Bytes)
+	assert.Equal(before.Received.Messages+2, stats.Received.Messages)
+	assert.Equal(before.Dropped+1, stats.Dropped)
+
+	p := stats.Peers[addr]
+	assert.Equal(Traffic{Messages: 1, Bytes: 100}, p.Sent)
+	assert.Equal(Traffic{Messages: 2, Bytes: 100}, p.Received)
+	assert.Equal(uint64(1), p.Dropped)
+	assert.Equal(TopicTraffic{Sent: Traffic{Messages: 1, Bytes: 100}}, p.Topics["tx"])
+	assert.Equal(TopicTraffic{Received: Traffic{Messages: 2, Bytes: 100}}, p.Topics["getdata"])
+	assert.Len(p.Topics, 2)
+
+	unmeterPeer(addr, c.meter)
+	assert.NotContains(GetBandwidthStats().Peers, addr)
+}
+
+// TestPeerStatus ensures the height and the latency of a peer are tracked
+// until it disconnects
+func TestPeerStatus(t *testing.T) {
+	assert := require.New(t)
+	addr := "10.0.0.2:7000"
+	client, srv := net.Pipe()
+	defer func() {
+		_ = srv.Close()
+	}()
+
+	c := &Connection{meter: meterPeer(addr, client)}
+	defer unmeterPeer(addr, c.meter)
+
+	block := make([]byte, 10)
+	block[0] = byte(topics.Block)
+	binary.LittleEndian.PutUint64(block[2:], 8)
+	c.sawBlock(block)
+	binary.LittleEndian.PutUint64(block[2:], 5)
+	c.sawBlock(block)
+
+	// a pong without a ping is ignored
+	c.ponged()
+	status, ok := GetPeerStatus(addr)
+	assert.True(ok)
+	assert.Equal(uint64(8), status.Height)
+	assert.Equal(time.Duration(0), status.Latency)
+
+	c.pinged()
+	time.Sleep(10 * time.Millisecond)
+	c.ponged()
+	status, _ = GetPeerStatus(addr)
+	assert.True(status.Latency >= 10*time.Millisecond)
+
+	assert.True(Disconnect(addr))
+	_, err := client.Write([]byte{0})
+	assert.Error(err)
+	assert.False(Disconnect("10.0.0.3:7000"))
+}
+
+type reputationFunc func(addr string, m Misbehaviour) bool
+
+func (f reputationFunc) Misbehaved(addr string, m Misbehaviour) bool {
+	return f(addr, m)
+}
+
+// TestTopicRateLimit ensures the messages exceeding the limit of their topic
+// are dropped, and reported as misbehaviours
+func TestTopicRateLimit(t *testing.T) {
+	assert := require.New(t)
+	cwd, err := os.Getwd()
+	assert.NoError(err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../../dusk.toml")
+	assert.NoError(err)
+	cfg.Mock(&r)
+
+	SetRateLimits(RateLimits{Topics: map[topics.Topic]float64{topics.Tx: 1}})
+	defer SetRateLimits(RateLimits{})
+
+	misbehaviours := make(chan Misbehaviour, 10)
+	factory := NewReaderFactory(NewMessageProcessor(eventbus.New()))
+	factory.SetReputation(reputationFunc(func(addr string, m Misbehaviour) bool {
+		misbehaviours <- m
+		return false
+	}))
+
+	peer, _, w, _ := testReader(t, factory)
+	defer func() {
+		_ = peer.Close()
+	}()
+
+	dropped := GetBandwidthStats().Dropped
+	for i := 0; i < 2; i++ {
+		buf := bytes.NewBuffer([]byte{byte(topics.Tx), 0, 1, 2})
+		assert.NoError(protocol.WriteFrame(buf, protocol.TestNet, checksum.Generate(buf.Bytes())))
+		_, err := w.Write(buf.Bytes())
+		assert.NoError(err)
+	}
+
+	// the first tx is processed, the second one is dropped
+	for {
+		select {
+		case m := <-misbehaviours:
+			if m == RateLimited {
+				assert.Equal(dropped+1, GetBandwidthStats().Dropped)
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("rate limited misbehaviour not reported")
+		}
+	}
+}
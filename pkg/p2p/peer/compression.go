@@ -0,0 +1,129 @@
+package peer
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/golang/snappy"
+)
+
+// DefaultCompressionThreshold is the size in bytes above which the messages
+// are compressed when no threshold is configured
+const DefaultCompressionThreshold = 1024
+
+// compression is the compression setting of the node. A zero threshold
+// disables the compression
+var compression struct {
+	threshold int
+}
+
+// SetCompression enables the compression of the messages of more than
+// threshold bytes sent to the peers supporting it. A threshold of zero or
+// less disables the compression
+func SetCompression(threshold int) {
+	if threshold < 0 {
+		threshold = 0
+	}
+
+	compression.threshold = threshold
+}
+
+// negotiateCompression tells if the messages sent to a peer with the given
+// services can be compressed
+func negotiateCompression(remote protocol.ServiceFlag) bool {
+	return compression.threshold > 0 && remote&protocol.Compressed != 0
+}
+
+// CompressionStats measures the bandwidth saved by the compression of the
+// messages, since the node started
+type CompressionStats struct {
+	// SentMessages is the amount of compressed messages sent
+	SentMessages uint64 `json:"sent_messages"`
+	// SentRawBytes is the size of the sent messages before the compression
+	SentRawBytes uint64 `json:"sent_raw_bytes"`
+	// SentBytes is the size of the sent messages after the compression
+	SentBytes uint64 `json:"sent_bytes"`
+	// ReceivedMessages is the amount of compressed messages received
+	ReceivedMessages uint64 `json:"received_messages"`
+	// ReceivedRawBytes is the size of the received messages once
+	// decompressed
+	ReceivedRawBytes uint64 `json:"received_raw_bytes"`
+	// ReceivedBytes is the size of the received messages before the
+	// decompression
+	ReceivedBytes uint64 `json:"received_bytes"`
+	// SavedBytes is the amount of bytes the compression saved, in both
+	// directions
+	SavedBytes uint64 `json:"saved_bytes"`
+}
+
+var compressionStats CompressionStats
+
+// GetCompressionStats returns the compression statistics of the node
+func GetCompressionStats() CompressionStats {
+	s := CompressionStats{
+		SentMessages:     atomic.LoadUint64(&compressionStats.SentMessages),
+		SentRawBytes:     atomic.LoadUint64(&compressionStats.SentRawBytes),
+		SentBytes:        atomic.LoadUint64(&compressionStats.SentBytes),
+		ReceivedMessages: atomic.LoadUint64(&compressionStats.ReceivedMessages),
+		ReceivedRawBytes: atomic.LoadUint64(&compressionStats.ReceivedRawBytes),
+		ReceivedBytes:    atomic.LoadUint64(&compressionStats.ReceivedBytes),
+	}
+
+	s.SavedBytes = s.SentRawBytes - s.SentBytes + s.ReceivedRawBytes - s.ReceivedBytes
+	return s
+}
+
+// compress replaces a message (its topic and payload) with its compressed
+// form, wrapped in a topics.Compressed message, if the peer supports it and
+// the message exceeds the threshold. Messages which do not shrink are sent
+// as they are
+func (c *Connection) compress(m *bytes.Buffer) {
+	if !c.canCompress || m.Len() <= compression.threshold {
+		return
+	}
+
+	raw := m.Bytes()
+	compressed := snappy.Encode(nil, raw)
+	if len(compressed)+1 >= len(raw) {
+		return
+	}
+
+	b := make([]byte, 1+len(compressed))
+	b[0] = byte(topics.Compressed)
+	copy(b[1:], compressed)
+
+	atomic.AddUint64(&compressionStats.SentMessages, 1)
+	atomic.AddUint64(&compressionStats.SentRawBytes, uint64(len(raw)))
+	atomic.AddUint64(&compressionStats.SentBytes, uint64(len(b)))
+	*m = *bytes.NewBuffer(b)
+}
+
+// decompress returns the message wrapped in a topics.Compressed message. Any
+// other message is returned as it is
+func decompress(message []byte) ([]byte, error) {
+	if len(message) == 0 || message[0] != byte(topics.Compressed) {
+		return message, nil
+	}
+
+	n, err := snappy.DecodedLen(message[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(n) > protocol.MaxFrameSize {
+		return nil, errors.New("decompressed message exceeds MaxFrameSize")
+	}
+
+	raw, err := snappy.Decode(make([]byte, n), message[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	atomic.AddUint64(&compressionStats.ReceivedMessages, 1)
+	atomic.AddUint64(&compressionStats.ReceivedRawBytes, uint64(len(raw)))
+	atomic.AddUint64(&compressionStats.ReceivedBytes, uint64(len(message)))
+	return raw, nil
+}
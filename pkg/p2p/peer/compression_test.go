@@ -0,0 +1,69 @@
+package peer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCompression ensures the large messages sent to the peers supporting
+// the compression are compressed, and restored on reception
+func TestCompression(t *testing.T) {
+	SetCompression(100)
+	defer SetCompression(0)
+
+	c := &Connection{canCompress: negotiateCompression(protocol.FullNode | protocol.Compressed)}
+	require.True(t, c.canCompress)
+	require.Equal(t, protocol.Compressed, localServices()&protocol.Compressed)
+
+	raw := append([]byte{byte(topics.Block)}, bytes.Repeat([]byte{1, 2, 3, 4}, 1000)...)
+	m := bytes.NewBuffer(append([]byte{}, raw...))
+	before := GetCompressionStats()
+	c.compress(m)
+	require.Equal(t, byte(topics.Compressed), m.Bytes()[0])
+	require.Less(t, m.Len(), len(raw))
+
+	restored, err := decompress(m.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, raw, restored)
+
+	stats := GetCompressionStats()
+	require.Equal(t, before.SentMessages+1, stats.SentMessages)
+	require.Equal(t, before.ReceivedMessages+1, stats.ReceivedMessages)
+	require.Equal(t, before.SavedBytes+2*uint64(len(raw)-m.Len()), stats.SavedBytes)
+
+	// small messages are sent as they are
+	m = bytes.NewBuffer([]byte{byte(topics.Ping)})
+	c.compress(m)
+	require.Equal(t, []byte{byte(topics.Ping)}, m.Bytes())
+
+	// so are the ones which do not shrink
+	random := make([]byte, 1000)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	m = bytes.NewBuffer(append([]byte{}, random...))
+	c.compress(m)
+	require.Equal(t, random, m.Bytes())
+
+	// and the ones sent to the peers without compression
+	c = &Connection{canCompress: negotiateCompression(protocol.FullNode)}
+	m = bytes.NewBuffer(append([]byte{}, raw...))
+	c.compress(m)
+	require.Equal(t, raw, m.Bytes())
+}
+
+// TestDecompressionLimit ensures the compressed messages can not expand
+// beyond MaxFrameSize
+func TestDecompressionLimit(t *testing.T) {
+	bomb := snappy.Encode(nil, make([]byte, protocol.MaxFrameSize+1))
+	_, err := decompress(append([]byte{byte(topics.Compressed)}, bomb...))
+	require.Error(t, err)
+
+	_, err = decompress([]byte{byte(topics.Compressed), 0xff})
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package peer
 import (
 	"bytes"
 	"net"
+	"time"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
@@ -12,12 +13,19 @@ import (
 // ReaderFactory is responsible for spawning peers. It provides them with the
 // reference to the message processor, which will process the received messages.
 type ReaderFactory struct {
-	processor *MessageProcessor
+	processor  *MessageProcessor
+	reputation Reputation
 }
 
 // NewReaderFactory returns an initialized ReaderFactory.
 func NewReaderFactory(processor *MessageProcessor) *ReaderFactory {
-	return &ReaderFactory{processor}
+	return &ReaderFactory{processor: processor}
+}
+
+// SetReputation sets the Reputation the spawned Readers report the
+// misbehaviours of their peer to
+func (f *ReaderFactory) SetReputation(r Reputation) {
+	f.reputation = r
 }
 
 // SpawnReader returns a Reader. It will still need to be launched by
@@ -32,6 +40,8 @@ func (f *ReaderFactory) SpawnReader(conn net.Conn, gossip *protocol.Gossip, dupe
 		Connection:   pconn,
 		responseChan: responseChan,
 		processor:    f.processor,
+		reputation:   f.reputation,
+		limiter:      newLimiter(rateLimits, time.Now()),
 	}
 
 	// On each new connection the node sends topics.Mempool to retrieve mempool
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
@@ -24,7 +26,11 @@ func (w *Writer) Handshake() error {
 		return err
 	}
 
-	return w.writeVerAck(w.gossip)
+	if err := w.writeVerAck(w.gossip); err != nil {
+		return err
+	}
+
+	return w.secure(true)
 }
 
 // Handshake with another peer.
@@ -41,7 +47,11 @@ func (p *Reader) Handshake() error {
 		return err
 	}
 
-	return p.readVerAck()
+	if err := p.readVerAck(); err != nil {
+		return err
+	}
+
+	return p.secure(false)
 }
 
 func (c *Connection) writeLocalMsgVersion(g *protocol.Gossip) error {
@@ -93,7 +103,76 @@ func (c *Connection) readRemoteMsgVersion() error {
 		return err
 	}
 
-	return verifyVersion(version.Version)
+	if err := verifyVersion(version.Version); err != nil {
+		return err
+	}
+
+	c.remoteNonce = version.Nonce
+	c.remoteServices = version.Services
+	c.remotePort = version.Port
+	c.remoteVersion = *version.Version
+	c.remoteHeight = version.Height
+	c.canCompress = negotiateCompression(version.Services)
+	return nil
+}
+
+// Inherit takes over the outcome of the handshake performed by another
+// Connection to the same peer, so that the Reader and the Writer of the peer
+// agree on it
+func (c *Connection) Inherit(o *Connection) {
+	c.remoteNonce = o.remoteNonce
+	c.remoteServices = o.remoteServices
+	c.remotePort = o.remotePort
+	c.remoteVersion = o.remoteVersion
+	c.remoteHeight = o.remoteHeight
+	c.remoteIdentity = o.remoteIdentity
+	c.canCompress = o.canCompress
+}
+
+// RemoteNonce returns the nonce the peer sent in its version message. It is
+// zero before the handshake, or if the peer does not send it
+func (c *Connection) RemoteNonce() uint64 {
+	return c.remoteNonce
+}
+
+// RemoteVersion returns the protocol version the peer sent in its version
+// message
+func (c *Connection) RemoteVersion() protocol.Version {
+	return c.remoteVersion
+}
+
+// RemoteHeight returns the height of the chain of the peer when it connected.
+// It is zero if the peer does not send it
+func (c *Connection) RemoteHeight() uint64 {
+	return c.remoteHeight
+}
+
+// RemoteServices returns the services the peer advertised in its version
+// message
+func (c *Connection) RemoteServices() protocol.ServiceFlag {
+	return c.remoteServices
+}
+
+// RemoteIsLight tells if the peer is a light node, which only wants the block
+// announcements out of the gossip
+func (c *Connection) RemoteIsLight() bool {
+	return c.remoteServices&protocol.LightNode != 0 && c.remoteServices&protocol.FullNode == 0
+}
+
+// RemoteListenAddr returns the address the peer accepts connections on, made
+// of the host of the connection and the port of its version message. It is
+// empty if the peer did not send its port
+func (c *Connection) RemoteListenAddr() string {
+	if c.remotePort == 0 {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(c.Addr())
+	if err != nil {
+		return ""
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(c.remotePort)))
 }
 
 func (c *Connection) readVerAck() error {
@@ -144,7 +223,7 @@ func (c *Connection) writeVerAck(g *protocol.Gossip) error {
 
 func (c *Connection) createVersionBuffer() (*bytes.Buffer, error) {
 	version := protocol.NodeVer
-	message, err := newVersionMessageBuffer(version, protocol.FullNode)
+	message, err := newVersionMessageBuffer(version, localServices())
 	if err != nil {
 		return nil, err
 	}
@@ -25,6 +25,9 @@ func TestHandshake(t *testing.T) {
 	require.Nil(t, err)
 	cfg.Mock(&r)
 
+	SetLocalHeight(12)
+	defer SetLocalHeight(0)
+
 	eb := eventbus.New()
 
 	processor := NewMessageProcessor(eb)
@@ -53,4 +56,8 @@ func TestHandshake(t *testing.T) {
 	if err := pw.Handshake(); err != nil {
 		t.Fatal(err)
 	}
+
+	// both ends run in this process
+	require.Equal(t, LocalNonce(), pw.RemoteNonce())
+	require.Equal(t, uint64(12), pw.RemoteHeight())
 }
@@ -0,0 +1,76 @@
+package peer
+
+import (
+	"fmt"
+)
+
+// Misbehaviour is a protocol violation by a peer. Each of them adds its Score
+// to the misbehaviour score of the peer
+type Misbehaviour uint8
+
+const (
+	// InvalidChecksum messages do not match their checksum
+	InvalidChecksum Misbehaviour = iota
+	// MalformedMessage messages can not be decoded
+	MalformedMessage
+	// InvalidBlock blocks or headers could not be accepted
+	InvalidBlock
+	// InvalidTx txs failed the verification
+	InvalidTx
+	// InvalidMessage messages of any other topic failed their processing
+	InvalidMessage
+	// RateLimited messages exceeded the rate limit of their topic
+	RateLimited
+)
+
+var misbehaviours = [...]struct {
+	name  string
+	score uint32
+}{
+	{"invalid checksum", 50},
+	{"malformed message", 50},
+	{"invalid block", 50},
+	{"invalid tx", 10},
+	{"invalid message", 5},
+	{"rate limited", 2},
+}
+
+func (m Misbehaviour) String() string {
+	if int(m) >= len(misbehaviours) {
+		return "unknown"
+	}
+	return misbehaviours[m].name
+}
+
+// Score of the misbehaviour
+func (m Misbehaviour) Score() uint32 {
+	if int(m) >= len(misbehaviours) {
+		return 0
+	}
+	return misbehaviours[m].score
+}
+
+// MisbehaviourError is returned by the MessageProcessor when a peer sent a
+// message it should not have. Processors return it for the messages which
+// prove the misbehaviour of their sender, any other processing error is only
+// logged
+type MisbehaviourError struct {
+	Misbehaviour Misbehaviour
+	Err          error
+}
+
+func (e *MisbehaviourError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Misbehaviour, e.Err)
+}
+
+// Unwrap returns the processing error
+func (e *MisbehaviourError) Unwrap() error {
+	return e.Err
+}
+
+// Reputation keeps track of the misbehaviours of the peers
+type Reputation interface {
+	// Misbehaved reports a misbehaviour of the peer at addr. It returns true
+	// if the peer must be disconnected
+	Misbehaved(addr string, m Misbehaviour) bool
+}
@@ -3,6 +3,7 @@ package peer
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -30,6 +31,20 @@ type Connection struct {
 	lock sync.Mutex
 	net.Conn
 	gossip *protocol.Gossip
+
+	// remoteNonce, remoteServices, remotePort, remoteVersion and
+	// remoteHeight are set by the handshake
+	remoteNonce    uint64
+	remoteServices protocol.ServiceFlag
+	remotePort     uint16
+	remoteVersion  protocol.Version
+	remoteHeight   uint64
+	// remoteIdentity is set once the connection is encrypted
+	remoteIdentity ed25519.PublicKey
+	// canCompress tells if the peer accepts compressed messages
+	canCompress bool
+	// meter counts the traffic with the peer once connected
+	meter *meter
 }
 
 // GossipConnector calls Gossip.Process on the message stream incoming from the
@@ -41,12 +56,25 @@ type GossipConnector struct {
 }
 
 func (g *GossipConnector) Write(b []byte) (int, error) {
+	// the light nodes request the headers of the announced blocks, and have
+	// no use for the rest of the gossip
+	if g.RemoteIsLight() && (len(b) == 0 || topics.Topic(b[0]) != topics.Inv) {
+		return len(b), nil
+	}
+
+	topic := topicOf(b)
 	buf := bytes.NewBuffer(b)
+	g.compress(buf)
 	if err := g.gossip.Process(buf); err != nil {
 		return 0, err
 	}
 
-	return g.Connection.Write(buf.Bytes())
+	n, err := g.Connection.Write(buf.Bytes())
+	if err == nil {
+		g.countSent(topic, n)
+	}
+
+	return n, err
 }
 
 // Writer abstracts all of the logic and fields needed to write messages to
@@ -64,6 +92,8 @@ type Writer struct {
 type Reader struct {
 	*Connection
 	processor    *MessageProcessor
+	reputation   Reputation
+	limiter      *limiter
 	responseChan chan<- bytes.Buffer
 	// TODO: add service flag
 }
@@ -187,6 +217,12 @@ func Create(ctx context.Context, reader *Reader, writer *Writer, writeQueueChan
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	addr := reader.Addr()
+	m := meterPeer(addr, reader.Conn)
+	defer unmeterPeer(addr, m)
+	reader.meter = m
+	writer.meter = m
+
 	errChan := make(chan error, 1)
 
 	go reader.ReadLoop(ctx, errChan)
@@ -274,16 +310,21 @@ func (w *Writer) writeLoop(ctx context.Context, writeQueueChan <-chan bytes.Buff
 	for {
 		select {
 		case buf := <-writeQueueChan:
+			topic := topicOf(buf.Bytes())
+			w.compress(&buf)
 			if err := w.gossip.Process(&buf); err != nil {
 				l.WithError(err).Warnln("error processing outgoing message")
 				continue
 			}
 
-			if _, err := w.Connection.Write(buf.Bytes()); err != nil {
+			n, err := w.Connection.Write(buf.Bytes())
+			if err != nil {
 				l.WithField("process", "writeloop").WithError(err).Warnln("error writing message")
 				sendError(errChan, err)
 				return
 			}
+
+			w.countSent(topic, n)
 		case <-ctx.Done():
 			log.WithField("process", "writeloop").Debug("context canceled")
 			return
@@ -347,23 +388,58 @@ func (p *Reader) readLoop(ctx context.Context, errChan chan error) {
 		message, cs, err := checksum.Extract(b)
 		if err != nil {
 			l.WithError(err).Warnln("error reading Extract message")
+			p.misbehaved(MalformedMessage)
 			sendError(errChan, err)
 			return
 		}
 
 		if !checksum.Verify(message, cs) {
-			l.WithError(errors.New("invalid checksum")).Warnln("error reading message")
+			err = errors.New("invalid checksum")
+			l.WithError(err).Warnln("error reading message")
+			p.misbehaved(InvalidChecksum)
+			sendError(errChan, err)
+			return
+		}
+
+		message, err = decompress(message)
+		if err != nil {
+			l.WithError(err).Warnln("error decompressing message")
+			p.misbehaved(MalformedMessage)
 			sendError(errChan, err)
 			return
 		}
 
+		// the peers exceeding their limit are throttled, and the messages
+		// exceeding the limit of their topic are dropped
+		topic := topicOf(message)
+		p.countReceived(topic, len(b))
+		if !p.limiter.throttle(ctx, p.Connection, len(b)) {
+			return
+		}
+
+		if !p.limiter.allow(topic) {
+			p.countDropped()
+			p.misbehaved(RateLimited)
+			continue
+		}
+
+		switch topic {
+		case topics.Block:
+			p.sawBlock(message)
+		case topics.Pong:
+			p.ponged()
+		}
+
 		go func() {
-			// TODO: error here should be checked in order to decrease reputation
-			// or blacklist spammers
 			startTime := time.Now().UnixNano()
-			if err = p.processor.Collect(message, p.responseChan); err != nil {
+			if err := p.processor.Collect(p.Addr(), message, p.responseChan); err != nil {
 				l.WithField("process", "readloop").
 					WithError(err).Error("failed to process message")
+
+				var me *MisbehaviourError
+				if errors.As(err, &me) {
+					p.misbehaved(me.Misbehaviour)
+				}
 			}
 
 			duration := float64(time.Now().UnixNano()-startTime) / 1000000
@@ -384,8 +460,7 @@ func (p *Reader) readLoop(ctx context.Context, errChan chan error) {
 					ID:       addr,
 					LastSeen: time.Now(),
 				}
-				err = store.Save(&peerCount)
-				if err != nil {
+				if err := store.Save(&peerCount); err != nil {
 					log.Error("failed to save peerCount into StormDB")
 				}
 			}()
@@ -393,6 +468,21 @@ func (p *Reader) readLoop(ctx context.Context, errChan chan error) {
 	}
 }
 
+// misbehaved reports a misbehaviour of the peer, and drops the connection if
+// the peer has to be disconnected. Closing the connection ends the readLoop
+func (p *Reader) misbehaved(m Misbehaviour) {
+	if p.reputation == nil {
+		return
+	}
+
+	if p.reputation.Misbehaved(p.Addr(), m) {
+		l.WithField("address", p.Addr()).
+			WithField("misbehaviour", m).
+			Warnln("disconnecting misbehaving peer")
+		_ = p.Conn.Close()
+	}
+}
+
 func (p *Reader) keepAliveLoop(ctx context.Context, timer *time.Timer) {
 	for {
 		select {
@@ -435,7 +525,12 @@ func (c *Connection) keepAlive() error {
 		return err
 	}
 
-	_, err := c.Write(buf.Bytes())
+	c.pinged()
+	n, err := c.Write(buf.Bytes())
+	if err == nil {
+		c.countSent(topics.Ping, n)
+	}
+
 	return err
 }
 
@@ -3,6 +3,7 @@ package peer
 import (
 	"bytes"
 	"context"
+	"errors"
 	"net"
 	"os"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/checksum"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
 	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
@@ -220,6 +222,33 @@ func TestInvalidPayload(t *testing.T) {
 	}
 }
 
+// TestMisbehaviourErrors ensures the processing errors tell the misbehaviour
+// of the peer
+func TestMisbehaviourErrors(t *testing.T) {
+	assert := require.New(t)
+	processor := NewMessageProcessor(eventbus.New())
+	processor.Register(topics.Ping, func(message.Message) ([]bytes.Buffer, error) {
+		return nil, errors.New("could not process ping")
+	})
+	processor.Register(topics.Pong, func(message.Message) ([]bytes.Buffer, error) {
+		return nil, &MisbehaviourError{Misbehaviour: InvalidMessage, Err: errors.New("invalid pong")}
+	})
+
+	// Processing errors are not misbehaviours, unless the processor says so
+	var me *MisbehaviourError
+	err := processor.Collect("10.0.0.1:7000", []byte{byte(topics.Ping)}, nil)
+	assert.Error(err)
+	assert.False(errors.As(err, &me))
+
+	err = processor.Collect("10.0.0.1:7000", []byte{byte(topics.Pong)}, nil)
+	assert.True(errors.As(err, &me))
+	assert.Equal(InvalidMessage, me.Misbehaviour)
+
+	err = processor.Collect("10.0.0.1:7000", []byte{byte(topics.Block), 0, 1, 2}, nil)
+	assert.True(errors.As(err, &me))
+	assert.Equal(MalformedMessage, me.Misbehaviour)
+}
+
 //nolint:unparam
 func testReader(t *testing.T, f *ReaderFactory) (*Reader, net.Conn, net.Conn, chan<- bytes.Buffer) {
 	d := dupemap.NewDupeMap(0)
@@ -161,3 +161,24 @@ func addPeer(bus *eventbus.EventBus, receiveFunc func(net.Conn)) *Writer {
 	go receiveFunc(srv)
 	return pw
 }
+
+// TestLightPeerGossip ensures the light nodes only get the block
+// announcements out of the gossip
+func TestLightPeerGossip(t *testing.T) {
+	SetLightNode(true)
+	assert.Equal(t, protocol.LightNode, localServices()&(protocol.FullNode|protocol.LightNode))
+	SetLightNode(false)
+	assert.Equal(t, protocol.FullNode, localServices()&(protocol.FullNode|protocol.LightNode))
+
+	// the connection is never written to, since the messages are dropped
+	g := &GossipConnector{protocol.NewGossip(protocol.TestNet), &Connection{remoteServices: protocol.LightNode}}
+	assert.True(t, g.RemoteIsLight())
+	for _, topic := range []topics.Topic{topics.Tx, topics.Block, topics.Candidate, topics.Agreement} {
+		n, err := g.Write([]byte{byte(topic), 1, 2, 3})
+		assert.NoError(t, err)
+		assert.Equal(t, 4, n)
+	}
+
+	c := &Connection{remoteServices: protocol.FullNode | protocol.LightNode}
+	assert.False(t, c.RemoteIsLight())
+}
@@ -0,0 +1,379 @@
+package peermgr
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+)
+
+const (
+	// maxAddrFailures is the amount of consecutive failed dials after which
+	// an address is forgotten
+	maxAddrFailures = 10
+
+	// maxNewAddrs is the maximum amount of addresses learned from the peers
+	// which the node did not connect to yet
+	maxNewAddrs = 1024
+	// maxAddrsPerSource is the maximum amount of new addresses learned from
+	// the peers of the same network group
+	maxAddrsPerSource = 64
+	// maxAddrsPerGroup is the maximum amount of new addresses in the same
+	// network group
+	maxAddrsPerGroup = 32
+	// maxAddrAge is the age after which the advertised addresses are ignored
+	maxAddrAge = 7 * 24 * time.Hour
+	// maxClockSkew is how far in the future an advertised timestamp can be
+	maxClockSkew = 10 * time.Minute
+)
+
+// AddrEntry is an address known by the AddrBook
+type AddrEntry struct {
+	Address string `json:"address"`
+	// LastSuccess is the last time the node connected to the address. It is
+	// zero if it never did
+	LastSuccess time.Time `json:"last_success"`
+	// Failures is the amount of consecutive failed dials
+	Failures int `json:"failures"`
+	// Services are the services the node advertised. They are zero if
+	// unknown
+	Services protocol.ServiceFlag `json:"services"`
+	// Seen is the last time the address was advertised as reachable
+	Seen time.Time `json:"seen"`
+	// Source is the network group of the peer the address was learned from.
+	// It is empty for the seeds and the good addresses
+	Source string `json:"source,omitempty"`
+}
+
+// AddrBook keeps track of the addresses the node can dial, and persists the
+// good ones, that is the ones it could connect to, across restarts.
+//
+// The addresses learned from the peers are new until the node connects to
+// them. To prevent a peer from filling the book with its own addresses, the
+// new addresses are capped per network group of the peer advertising them
+// (the source) and per network group of the address itself
+type AddrBook struct {
+	lock    sync.Mutex
+	path    string
+	entries map[string]*AddrEntry
+}
+
+// AddrBookFile returns the path of the address book, as configured or next
+// to the chain database
+func AddrBookFile() string {
+	if path := config.Get().Network.Peers.AddressBook; path != "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "peers.json")
+}
+
+// LoadAddrBook loads the address book persisted at path. A missing file
+// results in an empty AddrBook
+func LoadAddrBook(path string) (*AddrBook, error) {
+	b := &AddrBook{
+		path:    path,
+		entries: make(map[string]*AddrEntry),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return b, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]AddrEntry, 0)
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		b.entries[entries[i].Address] = &entries[i]
+	}
+
+	return b, nil
+}
+
+// Add an address, unless it is already known
+func (b *AddrBook) Add(addr string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if _, ok := b.entries[addr]; !ok {
+		b.entries[addr] = &AddrEntry{Address: addr}
+	}
+}
+
+// Good marks a successful connection to an address, which advertised the
+// given services
+func (b *AddrBook) Good(addr string, services protocol.ServiceFlag) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := time.Now()
+	b.entries[addr] = &AddrEntry{Address: addr, LastSuccess: now, Services: services, Seen: now}
+}
+
+// Remove an address
+func (b *AddrBook) Remove(addr string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.entries, addr)
+}
+
+// Learn the addresses advertised by the peer at src. Invalid and stale
+// addresses are ignored, as well as the new ones exceeding the caps of their
+// source or network group. It returns the amount of new addresses
+func (b *AddrBook) Learn(src string, addrs []message.NetAddress, now time.Time) int {
+	source := netGroup(hostOf(src))
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	perSource, perGroup := b.countNew()
+	learned := 0
+	for _, a := range addrs {
+		if a.IP == nil || a.IP.IsUnspecified() || a.Port == 0 {
+			continue
+		}
+
+		seen := time.Unix(a.Timestamp, 0)
+		if seen.After(now.Add(maxClockSkew)) {
+			seen = now
+		}
+
+		if now.Sub(seen) > maxAddrAge {
+			continue
+		}
+
+		addr := a.String()
+		if e, ok := b.entries[addr]; ok {
+			if seen.After(e.Seen) {
+				e.Seen = seen
+			}
+
+			if e.Services == 0 {
+				e.Services = a.Services
+			}
+			continue
+		}
+
+		group := netGroup(a.IP.String())
+		if perSource[source] >= maxAddrsPerSource || perGroup[group] >= maxAddrsPerGroup {
+			continue
+		}
+
+		if !b.makeRoom(source, perSource, perGroup) {
+			continue
+		}
+
+		b.entries[addr] = &AddrEntry{Address: addr, Services: a.Services, Seen: seen, Source: source}
+		perSource[source]++
+		perGroup[group]++
+		learned++
+	}
+
+	return learned
+}
+
+// countNew counts the new addresses per source and per network group
+func (b *AddrBook) countNew() (map[string]int, map[string]int) {
+	perSource := make(map[string]int)
+	perGroup := make(map[string]int)
+	for _, e := range b.entries {
+		if e.Source == "" {
+			continue
+		}
+
+		perSource[e.Source]++
+		perGroup[netGroup(hostOf(e.Address))]++
+	}
+
+	return perSource, perGroup
+}
+
+// makeRoom evicts the oldest new address of the largest source once the book
+// holds maxNewAddrs new addresses. It returns false if the largest source is
+// the one of the address to add, which is then dropped instead
+func (b *AddrBook) makeRoom(source string, perSource, perGroup map[string]int) bool {
+	total := 0
+	largest := ""
+	for s, n := range perSource {
+		total += n
+		if n > perSource[largest] || (n == perSource[largest] && s < largest) {
+			largest = s
+		}
+	}
+
+	if total < maxNewAddrs {
+		return true
+	}
+
+	if largest == source {
+		return false
+	}
+
+	var oldest *AddrEntry
+	for _, e := range b.entries {
+		if e.Source == largest && (oldest == nil || e.Seen.Before(oldest.Seen)) {
+			oldest = e
+		}
+	}
+
+	delete(b.entries, oldest.Address)
+	perSource[largest]--
+	perGroup[netGroup(hostOf(oldest.Address))]--
+	return true
+}
+
+// Sample returns up to n good addresses picked at random, except for the one
+// of exclude
+func (b *AddrBook) Sample(n int, exclude string) []message.NetAddress {
+	b.lock.Lock()
+	addrs := make([]message.NetAddress, 0, len(b.entries))
+	for _, e := range b.entries {
+		if e.LastSuccess.IsZero() || e.Address == exclude {
+			continue
+		}
+
+		if a, ok := toNetAddress(e.Address, e.Services, e.LastSuccess); ok {
+			addrs = append(addrs, a)
+		}
+	}
+	b.lock.Unlock()
+
+	rand.Shuffle(len(addrs), func(i, j int) {
+		addrs[i], addrs[j] = addrs[j], addrs[i]
+	})
+
+	if len(addrs) > n {
+		addrs = addrs[:n]
+	}
+
+	return addrs
+}
+
+// Failed marks a failed dial of an address. Addresses failing too many times
+// in a row are forgotten
+func (b *AddrBook) Failed(addr string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	e, ok := b.entries[addr]
+	if !ok {
+		return
+	}
+
+	e.Failures++
+	if e.Failures >= maxAddrFailures {
+		delete(b.entries, addr)
+	}
+}
+
+// Addrs returns the known addresses of full nodes. The most recently good
+// ones come first, followed by the ones the node never connected to, most
+// recently seen first
+func (b *AddrBook) Addrs() []string {
+	b.lock.Lock()
+	entries := make([]AddrEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		if e.Services == 0 || e.Services&protocol.FullNode != 0 {
+			entries = append(entries, *e)
+		}
+	}
+	b.lock.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].LastSuccess.Equal(entries[j].LastSuccess) {
+			return entries[i].LastSuccess.After(entries[j].LastSuccess)
+		}
+
+		if !entries[i].Seen.Equal(entries[j].Seen) {
+			return entries[i].Seen.After(entries[j].Seen)
+		}
+
+		return entries[i].Address < entries[j].Address
+	})
+
+	addrs := make([]string, len(entries))
+	for i, e := range entries {
+		addrs[i] = e.Address
+	}
+
+	return addrs
+}
+
+// Save persists the good addresses. The book is written to a temporary file
+// first, so that a crash while saving does not corrupt the previous one
+func (b *AddrBook) Save() error {
+	b.lock.Lock()
+	entries := make([]AddrEntry, 0, len(b.entries))
+	for _, e := range b.entries {
+		if !e.LastSuccess.IsZero() {
+			entries = append(entries, *e)
+		}
+	}
+	b.lock.Unlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Address < entries[j].Address
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := b.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, b.path)
+}
+
+// toNetAddress converts an address of the host:port form into a NetAddress.
+// It returns false if the host is not an IP
+func toNetAddress(addr string, services protocol.ServiceFlag, seen time.Time) (message.NetAddress, bool) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return message.NetAddress{}, false
+	}
+
+	ip := net.ParseIP(host)
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if ip == nil || err != nil {
+		return message.NetAddress{}, false
+	}
+
+	return message.NetAddress{
+		Timestamp: seen.Unix(),
+		Services:  services,
+		IP:        ip,
+		Port:      uint16(port),
+	}, true
+}
+
+// netGroup returns the network group of a host, that is its /16 for IPv4
+// and its /32 for IPv6. Hosts which are not IPs are their own group
+func netGroup(host string) string {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return host
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(16, 32)).String()
+	}
+
+	return ip.Mask(net.CIDRMask(32, 128)).String()
+}
@@ -0,0 +1,122 @@
+package peermgr
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestAddrBookPersistence tests that only the good addresses are persisted
+func TestAddrBookPersistence(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "addrbook")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "peers.json")
+	b, err := LoadAddrBook(path)
+	assert.NoError(err)
+	assert.Empty(b.Addrs())
+
+	b.Add("10.0.0.1:7000")
+	b.Good("10.0.0.2:7000", protocol.FullNode)
+	assert.Equal([]string{"10.0.0.2:7000", "10.0.0.1:7000"}, b.Addrs())
+	assert.NoError(b.Save())
+
+	b, err = LoadAddrBook(path)
+	assert.NoError(err)
+	assert.Equal([]string{"10.0.0.2:7000"}, b.Addrs())
+}
+
+// TestAddrBookFailures tests that the addresses failing too many times are
+// forgotten
+func TestAddrBookFailures(t *testing.T) {
+	assert := assert.New(t)
+	b, err := LoadAddrBook(filepath.Join(os.TempDir(), "missing", "peers.json"))
+	assert.NoError(err)
+
+	b.Good("10.0.0.1:7000", protocol.FullNode)
+	for i := 0; i < maxAddrFailures-1; i++ {
+		b.Failed("10.0.0.1:7000")
+	}
+	assert.Len(b.Addrs(), 1)
+
+	b.Failed("10.0.0.1:7000")
+	assert.Empty(b.Addrs())
+}
+
+// TestAddrBookLearn tests that the learned addresses are validated, and that
+// a single source or network group can not fill the book
+func TestAddrBookLearn(t *testing.T) {
+	assert := assert.New(t)
+	b, err := LoadAddrBook(filepath.Join(os.TempDir(), "missing", "peers.json"))
+	assert.NoError(err)
+
+	now := time.Now()
+	addrs := []message.NetAddress{
+		{Timestamp: now.Unix(), Services: protocol.FullNode, IP: net.ParseIP("10.1.0.1"), Port: 7000},
+		// stale
+		{Timestamp: now.Add(-2 * maxAddrAge).Unix(), IP: net.ParseIP("10.2.0.1"), Port: 7000},
+		// no port
+		{Timestamp: now.Unix(), IP: net.ParseIP("10.3.0.1")},
+		{Timestamp: now.Unix(), IP: net.IPv4zero, Port: 7000},
+	}
+	assert.Equal(1, b.Learn("192.168.0.1:7000", addrs, now))
+	assert.Equal([]string{"10.1.0.1:7000"}, b.Addrs())
+
+	// the addresses of the same network group are capped
+	addrs = addrs[:0]
+	for i := 0; i < 2*maxAddrsPerGroup; i++ {
+		addrs = append(addrs, message.NetAddress{Timestamp: now.Unix(), IP: net.IPv4(10, 4, 0, byte(i)), Port: 7000})
+	}
+	assert.Equal(maxAddrsPerGroup, b.Learn("192.168.0.1:7000", addrs, now))
+
+	// so are the addresses from the same source
+	addrs = addrs[:0]
+	for i := 0; i < 2*maxAddrsPerSource; i++ {
+		addrs = append(addrs, message.NetAddress{Timestamp: now.Unix(), IP: net.IPv4(10, byte(10+i), 0, 1), Port: 7000})
+	}
+	assert.Equal(maxAddrsPerSource-maxAddrsPerGroup-1, b.Learn("192.168.0.1:7000", addrs, now))
+	assert.Equal(maxAddrsPerSource, b.Learn("172.16.0.1:7000", addrs[maxAddrsPerSource:], now))
+
+	// the learned addresses are not shared until the node connected to them
+	assert.Empty(b.Sample(10, ""))
+	b.Good("10.1.0.1:7000", protocol.FullNode)
+	sample := b.Sample(10, "")
+	assert.Len(sample, 1)
+	assert.Equal("10.1.0.1:7000", sample[0].String())
+	assert.Empty(b.Sample(10, "10.1.0.1:7000"))
+}
+
+// TestAddrBookEviction tests that a full book evicts the new addresses of the
+// largest source
+func TestAddrBookEviction(t *testing.T) {
+	assert := assert.New(t)
+	b, err := LoadAddrBook(filepath.Join(os.TempDir(), "missing", "peers.json"))
+	assert.NoError(err)
+
+	now := time.Now()
+	for src := 0; len(b.entries) < maxNewAddrs; src++ {
+		addrs := make([]message.NetAddress, 0, maxAddrsPerSource)
+		for i := 0; i < maxAddrsPerSource; i++ {
+			ip := net.IPv4(byte(src), byte(i), 0, 1)
+			addrs = append(addrs, message.NetAddress{Timestamp: now.Unix(), IP: ip, Port: 7000})
+		}
+		b.Learn(net.IPv4(192, byte(src), 0, 1).String()+":7000", addrs, now)
+	}
+
+	// the sources which reached their cap can not get in anymore
+	a := []message.NetAddress{{Timestamp: now.Unix(), IP: net.ParseIP("11.0.0.1"), Port: 7000}}
+	assert.Equal(0, b.Learn("192.0.0.1:7000", a, now))
+
+	// while a new one takes the place of an address of the largest source
+	assert.Equal(1, b.Learn("172.16.0.1:7000", a, now))
+	assert.Len(b.entries, maxNewAddrs)
+}
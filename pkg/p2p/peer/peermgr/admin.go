@@ -0,0 +1,505 @@
+package peermgr
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/kadcast/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The PeerAdmin service is not part of dusk-protobuf. Its messages are
+// encoded in JSON (see rpc.JSONCodecName), and clients need to call it with
+// the rpc.JSONCallOption. None of its methods is an rpc.OpenRoutes, so that
+// it is only served behind the session authentication
+
+const (
+	// ListPeersRoute is the full method name of PeerAdmin.ListPeers
+	ListPeersRoute = "/node.PeerAdmin/ListPeers"
+	// ConnectRoute is the full method name of PeerAdmin.Connect
+	ConnectRoute = "/node.PeerAdmin/Connect"
+	// DisconnectRoute is the full method name of PeerAdmin.Disconnect
+	DisconnectRoute = "/node.PeerAdmin/Disconnect"
+	// BanRoute is the full method name of PeerAdmin.Ban
+	BanRoute = "/node.PeerAdmin/Ban"
+	// UnbanRoute is the full method name of PeerAdmin.Unban
+	UnbanRoute = "/node.PeerAdmin/Unban"
+	// GetRoutingTableRoute is the full method name of
+	// PeerAdmin.GetRoutingTable
+	GetRoutingTableRoute = "/node.PeerAdmin/GetRoutingTable"
+)
+
+type (
+	// ListPeersRequest is the (empty) request of PeerAdmin.ListPeers
+	ListPeersRequest struct{}
+
+	// PeerStatus is the PeerInfo of a connected peer, along with its
+	// traffic as observed by the node
+	PeerStatus struct {
+		PeerInfo
+		// Height is the highest block height the peer sent. It is zero
+		// until the peer sends a block
+		Height   uint64 `json:"height"`
+		BytesIn  uint64 `json:"bytes_in"`
+		BytesOut uint64 `json:"bytes_out"`
+		// Latency is the round trip time of the last keepalive ping
+		// answered by the peer
+		Latency time.Duration `json:"latency"`
+	}
+
+	// PeerList is the response of PeerAdmin.ListPeers
+	PeerList struct {
+		Peers []PeerStatus `json:"peers"`
+	}
+
+	// PeerRequest is the request of PeerAdmin.Connect and
+	// PeerAdmin.Disconnect
+	PeerRequest struct {
+		Address string `json:"address"`
+	}
+
+	// PeerResponse is the (empty) response of PeerAdmin.Connect and
+	// PeerAdmin.Disconnect
+	PeerResponse struct{}
+
+	// BanRequest is the request of PeerAdmin.Ban
+	BanRequest struct {
+		Host string `json:"host"`
+		// Duration of the ban in seconds. The BanDuration of the Manager
+		// applies if it is zero
+		Duration uint64 `json:"duration"`
+		Reason   string `json:"reason"`
+	}
+
+	// BanResponse carries the amount of peers disconnected by a ban
+	BanResponse struct {
+		Disconnected int `json:"disconnected"`
+	}
+
+	// UnbanRequest is the request of PeerAdmin.Unban. All the bans are
+	// lifted if Host is empty
+	UnbanRequest struct {
+		Host string `json:"host"`
+	}
+
+	// UnbanResponse carries the amount of lifted bans
+	UnbanResponse struct {
+		Unbanned int `json:"unbanned"`
+	}
+
+	// RoutingTableRequest is the (empty) request of
+	// PeerAdmin.GetRoutingTable
+	RoutingTableRequest struct{}
+
+	// KadcastPeer is a peer of the kadcast routing table
+	KadcastPeer struct {
+		Address string `json:"address"`
+		ID      string `json:"id"`
+	}
+
+	// KadcastBucket lists the peers of a bucket of the kadcast routing table
+	KadcastBucket struct {
+		Index uint8         `json:"index"`
+		Peers []KadcastPeer `json:"peers"`
+	}
+
+	// RoutingTable is the response of PeerAdmin.GetRoutingTable
+	RoutingTable struct {
+		LocalPeer KadcastPeer     `json:"local_peer"`
+		Buckets   []KadcastBucket `json:"buckets"`
+	}
+
+	// PeerAdminServer is the server API of the PeerAdmin service
+	PeerAdminServer interface {
+		ListPeers(context.Context, *ListPeersRequest) (*PeerList, error)
+		Connect(context.Context, *PeerRequest) (*PeerResponse, error)
+		Disconnect(context.Context, *PeerRequest) (*PeerResponse, error)
+		Ban(context.Context, *BanRequest) (*BanResponse, error)
+		Unban(context.Context, *UnbanRequest) (*UnbanResponse, error)
+		GetRoutingTable(context.Context, *RoutingTableRequest) (*RoutingTable, error)
+	}
+
+	// PeerAdminClient is the client API of the PeerAdmin service
+	PeerAdminClient interface {
+		ListPeers(context.Context, *ListPeersRequest, ...grpc.CallOption) (*PeerList, error)
+		Connect(context.Context, *PeerRequest, ...grpc.CallOption) (*PeerResponse, error)
+		Disconnect(context.Context, *PeerRequest, ...grpc.CallOption) (*PeerResponse, error)
+		Ban(context.Context, *BanRequest, ...grpc.CallOption) (*BanResponse, error)
+		Unban(context.Context, *UnbanRequest, ...grpc.CallOption) (*UnbanResponse, error)
+		GetRoutingTable(context.Context, *RoutingTableRequest, ...grpc.CallOption) (*RoutingTable, error)
+	}
+
+	peerAdminClient struct {
+		cc *grpc.ClientConn
+	}
+)
+
+// Admin is the PeerAdminServer of the node. It manages the peers through the
+// Manager
+type Admin struct {
+	m *Manager
+	// router is the kadcast routing table. It is nil if kadcast is disabled
+	router *kadcast.RoutingTable
+}
+
+// NewAdmin creates an Admin of the peers of m, and of the kadcast routing
+// table rt, which can be nil
+func NewAdmin(m *Manager, rt *kadcast.RoutingTable) *Admin {
+	return &Admin{m: m, router: rt}
+}
+
+// ListPeers as defined by PeerAdminServer
+func (a *Admin) ListPeers(ctx context.Context, req *ListPeersRequest) (*PeerList, error) {
+	peers := a.m.Peers()
+	list := &PeerList{Peers: make([]PeerStatus, 0, len(peers))}
+	for _, p := range peers {
+		s := PeerStatus{PeerInfo: p}
+		if ps, ok := peer.GetPeerStatus(p.Address); ok {
+			s.Height = ps.Height
+			s.BytesIn = ps.Bandwidth.Received.Bytes
+			s.BytesOut = ps.Bandwidth.Sent.Bytes
+			s.Latency = ps.Latency
+		}
+
+		if s.Height < p.StartHeight {
+			s.Height = p.StartHeight
+		}
+
+		list.Peers = append(list.Peers, s)
+	}
+
+	return list, nil
+}
+
+// Connect as defined by PeerAdminServer
+func (a *Admin) Connect(ctx context.Context, req *PeerRequest) (*PeerResponse, error) {
+	if req.Address == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing address")
+	}
+
+	if err := a.m.Connect(req.Address); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &PeerResponse{}, nil
+}
+
+// Disconnect as defined by PeerAdminServer
+func (a *Admin) Disconnect(ctx context.Context, req *PeerRequest) (*PeerResponse, error) {
+	if err := a.m.Disconnect(req.Address); err != nil {
+		return nil, statusOf(err)
+	}
+
+	return &PeerResponse{}, nil
+}
+
+// Ban as defined by PeerAdminServer
+func (a *Admin) Ban(ctx context.Context, req *BanRequest) (*BanResponse, error) {
+	if req.Host == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing host")
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "banned by the operator"
+	}
+
+	n := a.m.Ban(req.Host, time.Duration(req.Duration)*time.Second, reason)
+	return &BanResponse{Disconnected: n}, nil
+}
+
+// Unban as defined by PeerAdminServer
+func (a *Admin) Unban(ctx context.Context, req *UnbanRequest) (*UnbanResponse, error) {
+	return &UnbanResponse{Unbanned: a.m.ClearBans(req.Host)}, nil
+}
+
+// GetRoutingTable as defined by PeerAdminServer. It fails with
+// codes.Unavailable if kadcast is disabled
+func (a *Admin) GetRoutingTable(ctx context.Context, req *RoutingTableRequest) (*RoutingTable, error) {
+	if a.router == nil {
+		return nil, status.Error(codes.Unavailable, "kadcast is disabled")
+	}
+
+	rt := &RoutingTable{
+		LocalPeer: kadcastPeer(a.router.LpeerInfo),
+		Buckets:   make([]KadcastBucket, 0),
+	}
+
+	for _, b := range a.router.Buckets() {
+		bucket := KadcastBucket{Index: b.Index, Peers: make([]KadcastPeer, len(b.Peers))}
+		for i, p := range b.Peers {
+			bucket.Peers[i] = kadcastPeer(p)
+		}
+
+		rt.Buckets = append(rt.Buckets, bucket)
+	}
+
+	return rt, nil
+}
+
+func kadcastPeer(p encoding.PeerInfo) KadcastPeer {
+	return KadcastPeer{Address: p.Address(), ID: hex.EncodeToString(p.ID[:])}
+}
+
+// statusOf maps the errors of the Manager to gRPC status errors
+func statusOf(err error) error {
+	switch err {
+	case ErrNotStarted:
+		return status.Error(codes.Unavailable, err.Error())
+	case ErrAlreadyConnected:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case ErrBanned:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case ErrNotConnected:
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Unavailable, err.Error())
+	}
+}
+
+// RegisterPeerAdminServer registers the PeerAdmin service on a gRPC server
+func RegisterPeerAdminServer(s *grpc.Server, srv PeerAdminServer) {
+	s.RegisterService(&peerAdminServiceDesc, srv)
+}
+
+// NewPeerAdminClient creates a client of the PeerAdmin service
+func NewPeerAdminClient(cc *grpc.ClientConn) PeerAdminClient {
+	return &peerAdminClient{cc}
+}
+
+// ListPeers as defined by PeerAdminClient
+func (c *peerAdminClient) ListPeers(ctx context.Context, in *ListPeersRequest, opts ...grpc.CallOption) (*PeerList, error) {
+	out := new(PeerList)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, ListPeersRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Connect as defined by PeerAdminClient
+func (c *peerAdminClient) Connect(ctx context.Context, in *PeerRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, ConnectRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Disconnect as defined by PeerAdminClient
+func (c *peerAdminClient) Disconnect(ctx context.Context, in *PeerRequest, opts ...grpc.CallOption) (*PeerResponse, error) {
+	out := new(PeerResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, DisconnectRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Ban as defined by PeerAdminClient
+func (c *peerAdminClient) Ban(ctx context.Context, in *BanRequest, opts ...grpc.CallOption) (*BanResponse, error) {
+	out := new(BanResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, BanRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Unban as defined by PeerAdminClient
+func (c *peerAdminClient) Unban(ctx context.Context, in *UnbanRequest, opts ...grpc.CallOption) (*UnbanResponse, error) {
+	out := new(UnbanResponse)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, UnbanRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GetRoutingTable as defined by PeerAdminClient
+func (c *peerAdminClient) GetRoutingTable(ctx context.Context, in *RoutingTableRequest, opts ...grpc.CallOption) (*RoutingTable, error) {
+	out := new(RoutingTable)
+	opts = append(opts, rpc.JSONCallOption())
+	if err := c.cc.Invoke(ctx, GetRoutingTableRoute, in, out, opts...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func listPeersHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(ListPeersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).ListPeers(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListPeersRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).ListPeers(ctx, req.(*ListPeersRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func connectHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(PeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).Connect(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConnectRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).Connect(ctx, req.(*PeerRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func disconnectHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(PeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).Disconnect(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DisconnectRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).Disconnect(ctx, req.(*PeerRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func banHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(BanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).Ban(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BanRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).Ban(ctx, req.(*BanRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func unbanHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(UnbanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).Unban(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UnbanRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).Unban(ctx, req.(*UnbanRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func getRoutingTableHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) { //nolint
+	in := new(RoutingTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(PeerAdminServer).GetRoutingTable(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GetRoutingTableRoute,
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PeerAdminServer).GetRoutingTable(ctx, req.(*RoutingTableRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+var peerAdminServiceDesc = grpc.ServiceDesc{
+	ServiceName: "node.PeerAdmin",
+	HandlerType: (*PeerAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListPeers",
+			Handler:    listPeersHandler,
+		},
+		{
+			MethodName: "Connect",
+			Handler:    connectHandler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    disconnectHandler,
+		},
+		{
+			MethodName: "Ban",
+			Handler:    banHandler,
+		},
+		{
+			MethodName: "Unban",
+			Handler:    unbanHandler,
+		},
+		{
+			MethodName: "GetRoutingTable",
+			Handler:    getRoutingTableHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "p2p/peer/peermgr/admin.go",
+}
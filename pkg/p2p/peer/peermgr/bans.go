@@ -0,0 +1,143 @@
+package peermgr
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+)
+
+// Ban of a host
+type Ban struct {
+	Host   string    `json:"host"`
+	Until  time.Time `json:"until"`
+	Reason string    `json:"reason"`
+}
+
+// BanList keeps track of the banned hosts, and persists them across restarts
+type BanList struct {
+	lock sync.Mutex
+	path string
+	bans map[string]Ban
+}
+
+// BanListFile returns the path of the ban list, as configured or next to the
+// chain database
+func BanListFile() string {
+	if path := config.Get().Network.Peers.BanList; path != "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "bans.json")
+}
+
+// LoadBanList loads the ban list persisted at path. A missing file results in
+// an empty BanList
+func LoadBanList(path string) (*BanList, error) {
+	l := &BanList{
+		path: path,
+		bans: make(map[string]Ban),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return l, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]Ban, 0)
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, err
+	}
+
+	for _, b := range bans {
+		l.bans[b.Host] = b
+	}
+
+	return l, nil
+}
+
+// Ban a host until the given time
+func (l *BanList) Ban(host string, until time.Time, reason string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.bans[host] = Ban{Host: host, Until: until, Reason: reason}
+}
+
+// IsBanned tells if a host is banned at the given time. Expired bans are
+// forgotten
+func (l *BanList) IsBanned(host string, now time.Time) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	b, ok := l.bans[host]
+	if !ok {
+		return false
+	}
+
+	if !now.Before(b.Until) {
+		delete(l.bans, host)
+		return false
+	}
+
+	return true
+}
+
+// Unban a host. It returns false if the host was not banned
+func (l *BanList) Unban(host string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	_, ok := l.bans[host]
+	delete(l.bans, host)
+	return ok
+}
+
+// Clear lifts all bans. It returns the amount of lifted bans
+func (l *BanList) Clear() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	n := len(l.bans)
+	l.bans = make(map[string]Ban)
+	return n
+}
+
+// List returns the bans which did not expire at the given time, sorted by host
+func (l *BanList) List(now time.Time) []Ban {
+	l.lock.Lock()
+	bans := make([]Ban, 0, len(l.bans))
+	for _, b := range l.bans {
+		if now.Before(b.Until) {
+			bans = append(bans, b)
+		}
+	}
+	l.lock.Unlock()
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Host < bans[j].Host
+	})
+	return bans
+}
+
+// Save persists the bans which did not expire yet. The list is written to a
+// temporary file first, so that a crash while saving does not corrupt the
+// previous one
+func (l *BanList) Save() error {
+	data, err := json.MarshalIndent(l.List(time.Now()), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := l.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, l.path)
+}
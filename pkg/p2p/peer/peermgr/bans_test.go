@@ -0,0 +1,58 @@
+package peermgr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	assert "github.com/stretchr/testify/require"
+)
+
+// TestBanListPersistence tests that the bans which did not expire survive a
+// restart
+func TestBanListPersistence(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "peermgr")
+	assert.NoError(err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "bans.json")
+	l, err := LoadBanList(path)
+	assert.NoError(err)
+
+	now := time.Now()
+	l.Ban("10.0.0.1", now.Add(time.Hour), "invalid block")
+	l.Ban("10.0.0.2", now.Add(-time.Second), "invalid block")
+	assert.NoError(l.Save())
+
+	l, err = LoadBanList(path)
+	assert.NoError(err)
+	assert.True(l.IsBanned("10.0.0.1", now))
+	assert.False(l.IsBanned("10.0.0.2", now))
+
+	bans := l.List(now)
+	assert.Len(bans, 1)
+	assert.Equal("invalid block", bans[0].Reason)
+}
+
+// TestBanListExpiry tests that the bans are lifted once expired or cleared
+func TestBanListExpiry(t *testing.T) {
+	assert := assert.New(t)
+	l, err := LoadBanList(filepath.Join(os.TempDir(), "missing", "bans.json"))
+	assert.NoError(err)
+
+	now := time.Now()
+	l.Ban("10.0.0.1", now.Add(time.Minute), "")
+	l.Ban("10.0.0.2", now.Add(time.Minute), "")
+	l.Ban("10.0.0.3", now.Add(time.Minute), "")
+	assert.False(l.IsBanned("10.0.0.1", now.Add(time.Minute)))
+
+	assert.True(l.Unban("10.0.0.2"))
+	assert.False(l.Unban("10.0.0.2"))
+	assert.Equal(1, l.Clear())
+	assert.Empty(l.List(now))
+}
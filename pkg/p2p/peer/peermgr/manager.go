@@ -0,0 +1,796 @@
+package peermgr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	logger "github.com/sirupsen/logrus"
+)
+
+var log = logger.WithField("process", "peermgr")
+
+const (
+	// DefaultMaxInbound is the maximum amount of accepted connections when no
+	// MaxInbound is configured
+	DefaultMaxInbound = 64
+	// DefaultTargetOutbound is the amount of dialed connections when no
+	// TargetOutbound is configured
+	DefaultTargetOutbound = 8
+	// DefaultMinBackoff is the delay before redialing a peer when no
+	// MinBackoff is configured
+	DefaultMinBackoff = 5 * time.Second
+	// DefaultMaxBackoff is the longest delay before redialing a peer when no
+	// MaxBackoff is configured
+	DefaultMaxBackoff = 10 * time.Minute
+	// DefaultBanThreshold is the misbehaviour score banning a host when no
+	// BanThreshold is configured
+	DefaultBanThreshold = 100
+	// DefaultBanDuration is how long a host is banned when no BanDuration is
+	// configured
+	DefaultBanDuration = 24 * time.Hour
+
+	// scoreHalfLife is the time after which the misbehaviour score of a host
+	// is halved, so that occasional misbehaviours are forgiven
+	scoreHalfLife = 10 * time.Minute
+
+	// fillInterval is how often the Manager dials new peers when it is short
+	// of outbound connections
+	fillInterval = time.Second
+	// saveInterval is how often the AddrBook and the BanList are persisted,
+	// so that they survive a crash
+	saveInterval = 10 * time.Minute
+
+	// getAddrInterval is how often a peer can get addresses from the node
+	getAddrInterval = 10 * time.Minute
+	// getAddrResponse is the maximum amount of addresses sent to a peer
+	getAddrResponse = 250
+	// addrRate is the amount of addresses per second the node takes from a
+	// peer, on top of the response to its own GetAddr
+	addrRate = 0.1
+)
+
+var (
+	// ErrSelfConnection is returned when the node connected to itself
+	ErrSelfConnection = errors.New("connected to self")
+	// ErrAlreadyConnected is returned when the node is already connected to
+	// the same address or node
+	ErrAlreadyConnected = errors.New("already connected")
+	// ErrTooManyInbound is returned when the node accepted MaxInbound
+	// connections already
+	ErrTooManyInbound = errors.New("too many inbound connections")
+	// ErrBanned is returned when the host of a connection is banned
+	ErrBanned = errors.New("peer is banned")
+	// ErrNotConnected is returned when disconnecting an unknown peer
+	ErrNotConnected = errors.New("peer is not connected")
+	// ErrNotStarted is returned when connecting to a peer before the Manager
+	// started
+	ErrNotStarted = errors.New("peer manager is not started")
+)
+
+// Direction tells which side initiated a connection
+type Direction string
+
+const (
+	// Inbound connections were accepted by the node
+	Inbound Direction = "inbound"
+	// Outbound connections were dialed by the node
+	Outbound Direction = "outbound"
+)
+
+// Handshake is what a peer tells about itself in its version message
+type Handshake struct {
+	Nonce    uint64
+	Services protocol.ServiceFlag
+	// Version is the protocol version of the peer
+	Version string
+	// Height is the height of the chain of the peer when it connected
+	Height uint64
+	// ListenAddr is the address the peer accepts connections on. It is
+	// empty if unknown
+	ListenAddr string
+	// Identity is the identity key of the peer. It is nil if the connection
+	// is not encrypted
+	Identity []byte
+}
+
+// HandshakeOf returns the Handshake of the peer of a connection
+func HandshakeOf(c *peer.Connection) Handshake {
+	return Handshake{
+		Nonce:      c.RemoteNonce(),
+		Services:   c.RemoteServices(),
+		Version:    c.RemoteVersion().String(),
+		Height:     c.RemoteHeight(),
+		ListenAddr: c.RemoteListenAddr(),
+		Identity:   c.RemoteIdentity(),
+	}
+}
+
+// PeerInfo is the state of a connected peer
+type PeerInfo struct {
+	Address     string               `json:"address"`
+	ListenAddr  string               `json:"listen_address,omitempty"`
+	Nonce       uint64               `json:"nonce"`
+	Services    protocol.ServiceFlag `json:"services"`
+	Identity    string               `json:"identity,omitempty"`
+	Version     string               `json:"version"`
+	StartHeight uint64               `json:"start_height"`
+	Direction   Direction            `json:"direction"`
+	Since       time.Time            `json:"since"`
+
+	// addrTokens is the amount of addresses the node takes from the peer,
+	// refilled at addrRate since tokensUpdated
+	addrTokens    float64
+	tokensUpdated time.Time
+	// lastGetAddr is the last time the node sent addresses to the peer
+	lastGetAddr time.Time
+}
+
+// refill the addrTokens of the peer until now
+func (p *PeerInfo) refill(now time.Time) {
+	p.addrTokens += now.Sub(p.tokensUpdated).Seconds() * addrRate
+	if p.addrTokens > message.MaxAddrs {
+		p.addrTokens = message.MaxAddrs
+	}
+
+	p.tokensUpdated = now
+}
+
+// Config of the Manager
+type Config struct {
+	// LocalNonce is the nonce this node sends in its version messages
+	LocalNonce     uint64
+	MaxInbound     int
+	TargetOutbound int
+	MinBackoff     time.Duration
+	MaxBackoff     time.Duration
+	BanThreshold   uint32
+	BanDuration    time.Duration
+}
+
+// ConfigFromFile returns the Config of the Manager from the node
+// configuration, falling back to the defaults for the missing values
+func ConfigFromFile() Config {
+	conf := config.Get().Network.Peers
+	c := Config{
+		LocalNonce:     peer.LocalNonce(),
+		MaxInbound:     conf.MaxInbound,
+		TargetOutbound: conf.TargetOutbound,
+		MinBackoff:     time.Duration(conf.MinBackoff) * time.Second,
+		MaxBackoff:     time.Duration(conf.MaxBackoff) * time.Second,
+		BanThreshold:   conf.BanThreshold,
+		BanDuration:    time.Duration(conf.BanDuration) * time.Second,
+	}
+
+	if c.MaxInbound <= 0 {
+		c.MaxInbound = DefaultMaxInbound
+	}
+
+	if c.TargetOutbound <= 0 {
+		c.TargetOutbound = DefaultTargetOutbound
+	}
+
+	if c.MinBackoff <= 0 {
+		c.MinBackoff = DefaultMinBackoff
+	}
+
+	if c.MaxBackoff < c.MinBackoff {
+		c.MaxBackoff = DefaultMaxBackoff
+	}
+
+	if c.BanThreshold == 0 {
+		c.BanThreshold = DefaultBanThreshold
+	}
+
+	if c.BanDuration <= 0 {
+		c.BanDuration = DefaultBanDuration
+	}
+
+	return c
+}
+
+// retry schedules the next dial of an address
+type retry struct {
+	attempts int
+	next     time.Time
+}
+
+// score is the misbehaviour score of a host, decaying since updated
+type score struct {
+	value   float64
+	updated time.Time
+}
+
+// at returns the score decayed until now
+func (s score) at(now time.Time) float64 {
+	halvings := float64(now.Sub(s.updated)) / float64(scoreHalfLife)
+	return s.value * math.Pow(0.5, halvings)
+}
+
+// Manager keeps track of the connected peers. It limits the inbound
+// connections, keeps TargetOutbound outbound ones by dialing the addresses of
+// its AddrBook, redials with an exponential backoff, and refuses several
+// connections to the same node. It also scores the misbehaviours of the hosts
+// and bans the ones reaching BanThreshold, and exchanges addresses with the
+// peers through GetAddr and Addr messages.
+//
+// The Manager does not own the connections: the node reports them through
+// Connected and Disconnected, and the Manager dials through the connect
+// function passed to Start
+type Manager struct {
+	cfg     Config
+	book    *AddrBook
+	bans    *BanList
+	connect func(addr string) error
+	// disconnect closes the connection to a peer, registered by
+	// peer.Create
+	disconnect func(addr string) bool
+
+	getPeersChan            <-chan rpcbus.Request
+	getBansChan             <-chan rpcbus.Request
+	clearBansChan           <-chan rpcbus.Request
+	getCompressionStatsChan <-chan rpcbus.Request
+	getBandwidthStatsChan   <-chan rpcbus.Request
+
+	lock    sync.Mutex
+	peers   map[string]*PeerInfo
+	dialing map[string]struct{}
+	retries map[string]*retry
+	scores  map[string]score
+
+	// saveLock serializes the periodic saves with the one on Stop
+	saveLock sync.Mutex
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+// New returns a Manager dialing the addresses of book and refusing the hosts
+// of bans. If rpcBus is not nil, the Manager answers topics.GetPeers,
+// topics.GetBans, topics.ClearBans, topics.GetCompressionStats and
+// topics.GetBandwidthStats once started
+func New(cfg Config, book *AddrBook, bans *BanList, rpcBus *rpcbus.RPCBus) *Manager {
+	m := &Manager{
+		cfg:        cfg,
+		book:       book,
+		bans:       bans,
+		disconnect: peer.Disconnect,
+		peers:      make(map[string]*PeerInfo),
+		dialing:    make(map[string]struct{}),
+		retries:    make(map[string]*retry),
+		scores:     make(map[string]score),
+		quit:       make(chan struct{}),
+	}
+
+	if rpcBus != nil {
+		m.getPeersChan = register(rpcBus, topics.GetPeers)
+		m.getBansChan = register(rpcBus, topics.GetBans)
+		m.clearBansChan = register(rpcBus, topics.ClearBans)
+		m.getCompressionStatsChan = register(rpcBus, topics.GetCompressionStats)
+		m.getBandwidthStatsChan = register(rpcBus, topics.GetBandwidthStats)
+	}
+
+	return m
+}
+
+func register(rpcBus *rpcbus.RPCBus, topic topics.Topic) <-chan rpcbus.Request {
+	reqChan := make(chan rpcbus.Request, 1)
+	if err := rpcBus.Register(topic, reqChan); err != nil {
+		log.WithError(err).Errorf("failed to register topics.%s", topic)
+	}
+
+	return reqChan
+}
+
+// Start dialing the seeds and the addresses of the AddrBook through connect,
+// which returns once the connection is established (and reported through
+// Connected) or failed
+func (m *Manager) Start(connect func(addr string) error, seeds []string) {
+	m.lock.Lock()
+	m.connect = connect
+	m.lock.Unlock()
+	for _, addr := range seeds {
+		m.book.Add(addr)
+	}
+
+	go func() {
+		ticker := time.NewTicker(fillInterval)
+		defer ticker.Stop()
+		saveTicker := time.NewTicker(saveInterval)
+		defer saveTicker.Stop()
+
+		m.fill(time.Now())
+		for {
+			select {
+			case r := <-m.getPeersChan:
+				r.RespChan <- rpcbus.NewResponse(m.Peers(), nil)
+			case r := <-m.getBansChan:
+				r.RespChan <- rpcbus.NewResponse(m.Bans(), nil)
+			case r := <-m.clearBansChan:
+				host, _ := r.Params.(string)
+				r.RespChan <- rpcbus.NewResponse(m.ClearBans(host), nil)
+			case r := <-m.getCompressionStatsChan:
+				r.RespChan <- rpcbus.NewResponse(peer.GetCompressionStats(), nil)
+			case r := <-m.getBandwidthStatsChan:
+				r.RespChan <- rpcbus.NewResponse(peer.GetBandwidthStats(), nil)
+			case now := <-ticker.C:
+				m.fill(now)
+			case <-saveTicker.C:
+				if err := m.save(); err != nil {
+					log.WithError(err).Warnln("could not save the address book")
+				}
+			case <-m.quit:
+				return
+			}
+		}
+	}()
+}
+
+// Stop dialing and persist the AddrBook and the BanList. It can be called
+// several times
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		close(m.quit)
+	})
+
+	return m.save()
+}
+
+// save persists the AddrBook and the BanList
+func (m *Manager) save() error {
+	m.saveLock.Lock()
+	defer m.saveLock.Unlock()
+	if err := m.bans.Save(); err != nil {
+		return err
+	}
+
+	return m.book.Save()
+}
+
+// AllowInbound tells if the node can accept another connection from addr. It
+// allows to drop the exceeding and banned connections before the handshake
+func (m *Manager) AllowInbound(addr string) bool {
+	if m.bans.IsBanned(hostOf(addr), time.Now()) {
+		return false
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.count(Inbound) < m.cfg.MaxInbound
+}
+
+// Connected registers a connection once the handshake is completed. The
+// connection must be closed if an error is returned. The outbound peers are
+// expected to be sent a GetAddr message (see MarshalGetAddr)
+func (m *Manager) Connected(addr string, hs Handshake, dir Direction) error {
+	nonce := hs.Nonce
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if dir == Outbound {
+		delete(m.dialing, addr)
+	}
+
+	if nonce != 0 && nonce == m.cfg.LocalNonce {
+		return ErrSelfConnection
+	}
+
+	if m.bans.IsBanned(hostOf(addr), time.Now()) {
+		return ErrBanned
+	}
+
+	if _, ok := m.peers[addr]; ok {
+		return ErrAlreadyConnected
+	}
+
+	if nonce != 0 {
+		for _, p := range m.peers {
+			if p.Nonce == nonce {
+				return ErrAlreadyConnected
+			}
+		}
+	}
+
+	if dir == Inbound && m.count(Inbound) >= m.cfg.MaxInbound {
+		return ErrTooManyInbound
+	}
+
+	now := time.Now()
+	p := &PeerInfo{
+		Address:       addr,
+		ListenAddr:    hs.ListenAddr,
+		Nonce:         nonce,
+		Services:      hs.Services,
+		Identity:      hex.EncodeToString(hs.Identity),
+		Version:       hs.Version,
+		Direction:     dir,
+		Since:         now,
+		StartHeight:   hs.Height,
+		tokensUpdated: now,
+	}
+	m.peers[addr] = p
+
+	switch dir {
+	case Outbound:
+		delete(m.retries, addr)
+		m.book.Good(addr, hs.Services)
+		// the response to our GetAddr
+		p.addrTokens = message.MaxAddrs
+	case Inbound:
+		// the peer is dialed (and its address checked) once the outbound
+		// connections are short
+		if a, ok := toNetAddress(hs.ListenAddr, hs.Services, now); ok {
+			m.book.Learn(addr, []message.NetAddress{a}, now)
+		}
+	}
+
+	log.WithField("address", addr).
+		WithField("direction", dir).
+		Debugln("peer connected")
+	return nil
+}
+
+// Disconnected unregisters a connection. Outbound peers are redialed after
+// MinBackoff
+func (m *Manager) Disconnected(addr string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	p, ok := m.peers[addr]
+	if !ok {
+		return
+	}
+
+	delete(m.peers, addr)
+	if p.Direction == Outbound {
+		m.retries[addr] = &retry{next: time.Now().Add(m.cfg.MinBackoff)}
+	}
+
+	log.WithField("address", addr).
+		WithField("direction", p.Direction).
+		Debugln("peer disconnected")
+}
+
+// Peers returns the connected peers, sorted by address
+func (m *Manager) Peers() []PeerInfo {
+	m.lock.Lock()
+	peers := make([]PeerInfo, 0, len(m.peers))
+	for _, p := range m.peers {
+		peers = append(peers, *p)
+	}
+	m.lock.Unlock()
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].Address < peers[j].Address
+	})
+	return peers
+}
+
+// Misbehaved adds the score of a misbehaviour to the score of the host of
+// addr. The host is banned for BanDuration once its score reaches
+// BanThreshold. It returns true if the peer must be disconnected. It complies
+// with the peer.Reputation interface
+func (m *Manager) Misbehaved(addr string, mb peer.Misbehaviour) bool {
+	host := hostOf(addr)
+	now := time.Now()
+	if m.bans.IsBanned(host, now) {
+		return true
+	}
+
+	m.lock.Lock()
+	s := score{value: m.scores[host].at(now) + float64(mb.Score()), updated: now}
+	banned := s.value >= float64(m.cfg.BanThreshold)
+	if banned {
+		delete(m.scores, host)
+	} else {
+		m.scores[host] = s
+	}
+	m.lock.Unlock()
+
+	log.WithField("address", addr).
+		WithField("misbehaviour", mb).
+		WithField("score", s.value).
+		Debugln("peer misbehaved")
+
+	if banned {
+		m.bans.Ban(host, now.Add(m.cfg.BanDuration), mb.String())
+		log.WithField("host", host).
+			WithField("reason", mb).
+			Warnln("peer banned")
+	}
+
+	return banned
+}
+
+// ProcessGetAddr answers a GetAddr message with an Addr message of up to
+// getAddrResponse good addresses. A peer gets addresses at most once every
+// getAddrInterval. It complies with the peer.PeerProcessorFunc interface
+func (m *Manager) ProcessGetAddr(srcPeer string, _ message.Message) ([]bytes.Buffer, error) {
+	now := time.Now()
+	m.lock.Lock()
+	p, ok := m.peers[srcPeer]
+	if !ok || (!p.lastGetAddr.IsZero() && now.Sub(p.lastGetAddr) < getAddrInterval) {
+		m.lock.Unlock()
+		return nil, nil
+	}
+
+	p.lastGetAddr = now
+	exclude := p.ListenAddr
+	if p.Direction == Outbound {
+		exclude = p.Address
+	}
+	m.lock.Unlock()
+
+	addrs := m.book.Sample(getAddrResponse, exclude)
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+
+	buf, err := message.Marshal(message.New(topics.Addr, message.Addr{Addrs: addrs}))
+	if err != nil {
+		return nil, err
+	}
+
+	return []bytes.Buffer{buf}, nil
+}
+
+// ProcessAddr adds the addresses of an Addr message to the AddrBook. The
+// addresses exceeding the rate limit of the peer are dropped. It complies
+// with the peer.PeerProcessorFunc interface
+func (m *Manager) ProcessAddr(srcPeer string, msg message.Message) ([]bytes.Buffer, error) {
+	addrs := msg.Payload().(message.Addr).Addrs
+	now := time.Now()
+
+	m.lock.Lock()
+	p, ok := m.peers[srcPeer]
+	if !ok {
+		m.lock.Unlock()
+		return nil, nil
+	}
+
+	p.refill(now)
+	if allowed := int(p.addrTokens); len(addrs) > allowed {
+		log.WithField("address", srcPeer).
+			WithField("dropped", len(addrs)-allowed).
+			Debugln("addresses over the rate limit")
+		addrs = addrs[:allowed]
+	}
+
+	p.addrTokens -= float64(len(addrs))
+	m.lock.Unlock()
+
+	learned := m.book.Learn(srcPeer, addrs, now)
+	log.WithField("address", srcPeer).
+		WithField("learned", learned).
+		Debugln("addresses received")
+	return nil, nil
+}
+
+// MarshalGetAddr returns a GetAddr message, asking a peer for addresses
+func MarshalGetAddr() (bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := topics.Prepend(buf, topics.GetAddr); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return *buf, nil
+}
+
+// Bans returns the banned hosts
+func (m *Manager) Bans() []Ban {
+	return m.bans.List(time.Now())
+}
+
+// Connect dials addr on demand, whether the outbound connections are short
+// or not. It returns once the connection is established or failed
+func (m *Manager) Connect(addr string) error {
+	m.lock.Lock()
+	connect := m.connect
+	if connect == nil {
+		m.lock.Unlock()
+		return ErrNotStarted
+	}
+
+	_, connected := m.peers[addr]
+	_, dialing := m.dialing[addr]
+	if connected || dialing {
+		m.lock.Unlock()
+		return ErrAlreadyConnected
+	}
+
+	if m.bans.IsBanned(hostOf(addr), time.Now()) {
+		m.lock.Unlock()
+		return ErrBanned
+	}
+
+	m.dialing[addr] = struct{}{}
+	m.lock.Unlock()
+
+	if err := connect(addr); err != nil {
+		m.lock.Lock()
+		delete(m.dialing, addr)
+		m.lock.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// Disconnect closes the connection to the peer at addr. Outbound peers are
+// redialed after MinBackoff, unless banned
+func (m *Manager) Disconnect(addr string) error {
+	m.lock.Lock()
+	_, ok := m.peers[addr]
+	m.lock.Unlock()
+	if !ok || !m.disconnect(addr) {
+		return ErrNotConnected
+	}
+
+	return nil
+}
+
+// Ban bans host for the given duration, or for BanDuration if it is zero,
+// and disconnects its peers. The port of host, if any, is ignored. It returns
+// the amount of disconnected peers
+func (m *Manager) Ban(host string, duration time.Duration, reason string) int {
+	host = hostOf(host)
+	if duration <= 0 {
+		duration = m.cfg.BanDuration
+	}
+
+	m.bans.Ban(host, time.Now().Add(duration), reason)
+	log.WithField("host", host).
+		WithField("reason", reason).
+		Warnln("peer banned")
+
+	m.lock.Lock()
+	var addrs []string
+	for addr := range m.peers {
+		if hostOf(addr) == host {
+			addrs = append(addrs, addr)
+		}
+	}
+	m.lock.Unlock()
+
+	disconnected := 0
+	for _, addr := range addrs {
+		if m.disconnect(addr) {
+			disconnected++
+		}
+	}
+
+	return disconnected
+}
+
+// ClearBans lifts the ban of host, or all of them if host is empty. It
+// returns the amount of lifted bans
+func (m *Manager) ClearBans(host string) int {
+	if host == "" {
+		return m.bans.Clear()
+	}
+
+	if m.bans.Unban(host) {
+		return 1
+	}
+
+	return 0
+}
+
+// fill dials the addresses which are due, until the outbound connections
+// (including the pending ones) reach TargetOutbound
+func (m *Manager) fill(now time.Time) {
+	addrs := m.book.Addrs()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	missing := m.cfg.TargetOutbound - m.count(Outbound) - len(m.dialing)
+	for _, addr := range addrs {
+		if missing <= 0 {
+			return
+		}
+
+		if !m.canDial(addr, now) {
+			continue
+		}
+
+		m.dialing[addr] = struct{}{}
+		missing--
+		go m.dial(addr)
+	}
+}
+
+func (m *Manager) canDial(addr string, now time.Time) bool {
+	if _, ok := m.peers[addr]; ok {
+		return false
+	}
+
+	if _, ok := m.dialing[addr]; ok {
+		return false
+	}
+
+	if m.bans.IsBanned(hostOf(addr), now) {
+		return false
+	}
+
+	r, ok := m.retries[addr]
+	return !ok || !now.Before(r.next)
+}
+
+func (m *Manager) dial(addr string) {
+	err := m.connect(addr)
+	if err == nil {
+		return
+	}
+
+	log.WithField("address", addr).
+		WithError(err).
+		Debugln("could not connect to peer")
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.dialing, addr)
+
+	r, ok := m.retries[addr]
+	if !ok {
+		r = &retry{}
+		m.retries[addr] = r
+	}
+
+	r.next = time.Now().Add(m.backoff(r.attempts))
+	r.attempts++
+
+	switch {
+	case errors.Is(err, ErrSelfConnection):
+		// our own address, advertised by a peer
+		m.book.Remove(addr)
+	case errors.Is(err, ErrAlreadyConnected):
+		// a duplicate connection says nothing about the address
+	default:
+		m.book.Failed(addr)
+	}
+}
+
+// backoff returns the delay before the next dial of an address which failed
+// attempts times already
+func (m *Manager) backoff(attempts int) time.Duration {
+	d := m.cfg.MinBackoff
+	for i := 0; i < attempts && d < m.cfg.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > m.cfg.MaxBackoff {
+		d = m.cfg.MaxBackoff
+	}
+
+	return d
+}
+
+func (m *Manager) count(dir Direction) int {
+	n := 0
+	for _, p := range m.peers {
+		if p.Direction == dir {
+			n++
+		}
+	}
+
+	return n
+}
+
+// hostOf returns the host of an address, which is what bans apply to since
+// the port of the inbound connections is not meaningful
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+
+	return host
+}
@@ -0,0 +1,276 @@
+package peermgr
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	assert "github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const localNonce = 42
+
+func newTestManager(t *testing.T, cfg Config) (*Manager, func()) {
+	dir, err := ioutil.TempDir("", "peermgr")
+	assert.NoError(t, err)
+
+	book, err := LoadAddrBook(filepath.Join(dir, "peers.json"))
+	assert.NoError(t, err)
+
+	bans, err := LoadBanList(filepath.Join(dir, "bans.json"))
+	assert.NoError(t, err)
+
+	cfg.LocalNonce = localNonce
+	return New(cfg, book, bans, nil), func() { _ = os.RemoveAll(dir) }
+}
+
+// waitDials waits for the pending dials to be over
+func waitDials(t *testing.T, m *Manager) {
+	assert.Eventually(t, func() bool {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		return len(m.dialing) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+// TestConnectionLimits tests that the Manager limits the inbound connections
+// and refuses several connections to the same node
+func TestConnectionLimits(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MaxInbound: 1, TargetOutbound: 1, MinBackoff: time.Second, MaxBackoff: time.Minute})
+	defer cleanup()
+
+	assert.True(m.AllowInbound("10.0.0.2:5555"))
+	assert.NoError(m.Connected("10.0.0.1:5555", Handshake{Nonce: 1}, Inbound))
+	assert.False(m.AllowInbound("10.0.0.2:5555"))
+	assert.True(errors.Is(m.Connected("10.0.0.2:5555", Handshake{Nonce: 2}, Inbound), ErrTooManyInbound))
+
+	// the same node, behind another address
+	assert.True(errors.Is(m.Connected("10.0.0.3:7000", Handshake{Nonce: 1}, Outbound), ErrAlreadyConnected))
+	// the same address
+	assert.True(errors.Is(m.Connected("10.0.0.1:5555", Handshake{Nonce: 3}, Outbound), ErrAlreadyConnected))
+	assert.True(errors.Is(m.Connected("10.0.0.4:7000", Handshake{Nonce: localNonce}, Outbound), ErrSelfConnection))
+
+	assert.NoError(m.Connected("10.0.0.5:7000", Handshake{}, Outbound))
+	assert.Len(m.Peers(), 2)
+
+	m.Disconnected("10.0.0.1:5555")
+	assert.True(m.AllowInbound("10.0.0.2:5555"))
+	assert.Len(m.Peers(), 1)
+}
+
+// TestFillOutbound tests that the Manager dials up to TargetOutbound
+// addresses, and redials the failing ones with a backoff
+func TestFillOutbound(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MaxInbound: 1, TargetOutbound: 2, MinBackoff: time.Minute, MaxBackoff: time.Hour})
+	defer cleanup()
+
+	dialed := make(chan string, 10)
+	m.connect = func(addr string) error {
+		defer func() { dialed <- addr }()
+		if addr == "10.0.0.1:7000" {
+			return errors.New("connection refused")
+		}
+		return m.Connected(addr, Handshake{}, Outbound)
+	}
+
+	for _, addr := range []string{"10.0.0.1:7000", "10.0.0.2:7000", "10.0.0.3:7000"} {
+		m.book.Add(addr)
+	}
+
+	now := time.Now()
+	m.fill(now)
+	first := map[string]bool{<-dialed: true, <-dialed: true}
+	assert.True(first["10.0.0.1:7000"])
+	waitDials(t, m)
+
+	// the failed address is replaced by the remaining one
+	m.fill(now)
+	assert.Equal("10.0.0.3:7000", <-dialed)
+	waitDials(t, m)
+	assert.Len(m.Peers(), 2)
+
+	// a dropped peer is redialed once its backoff expired
+	m.Disconnected("10.0.0.2:7000")
+	m.fill(now)
+	assert.Len(dialed, 0)
+
+	m.fill(now.Add(2 * time.Minute))
+	redialed := <-dialed
+	assert.Contains([]string{"10.0.0.1:7000", "10.0.0.2:7000"}, redialed)
+}
+
+func TestBackoff(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MinBackoff: time.Second, MaxBackoff: 4 * time.Second})
+	defer cleanup()
+
+	assert.Equal(time.Second, m.backoff(0))
+	assert.Equal(2*time.Second, m.backoff(1))
+	assert.Equal(4*time.Second, m.backoff(2))
+	assert.Equal(4*time.Second, m.backoff(10))
+}
+
+// TestMisbehaviourBan tests that the hosts reaching the ban threshold are
+// banned, and that their connections are refused
+func TestMisbehaviourBan(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MaxInbound: 8, BanThreshold: 100, BanDuration: time.Hour})
+	defer cleanup()
+
+	assert.NoError(m.Connected("10.0.0.1:5555", Handshake{Nonce: 1}, Inbound))
+	assert.False(m.Misbehaved("10.0.0.1:5555", peer.InvalidBlock))
+	assert.True(m.Misbehaved("10.0.0.1:5555", peer.InvalidChecksum))
+
+	// the ban applies to the host, whatever the port
+	assert.False(m.AllowInbound("10.0.0.1:6666"))
+	m.Disconnected("10.0.0.1:5555")
+	assert.True(errors.Is(m.Connected("10.0.0.1:6666", Handshake{Nonce: 2}, Inbound), ErrBanned))
+	assert.True(m.AllowInbound("10.0.0.2:5555"))
+
+	assert.Len(m.Bans(), 1)
+	assert.Equal(1, m.ClearBans("10.0.0.1"))
+	assert.NoError(m.Connected("10.0.0.1:6666", Handshake{Nonce: 2}, Inbound))
+}
+
+// TestAdminPeers tests that the operators can connect, disconnect and ban
+// peers through the Admin service
+func TestAdminPeers(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MaxInbound: 8, TargetOutbound: 8, BanDuration: time.Hour})
+	defer cleanup()
+
+	admin := NewAdmin(m, nil)
+	ctx := context.Background()
+	_, err := admin.Connect(ctx, &PeerRequest{Address: "10.0.0.1:7000"})
+	assert.Equal(codes.Unavailable, status.Code(err))
+
+	m.connect = func(addr string) error {
+		return m.Connected(addr, Handshake{Version: "0.1.0", Height: 10}, Outbound)
+	}
+
+	disconnected := make(map[string]bool)
+	m.disconnect = func(addr string) bool {
+		disconnected[addr] = true
+		m.Disconnected(addr)
+		return true
+	}
+
+	_, err = admin.Connect(ctx, &PeerRequest{Address: "10.0.0.1:7000"})
+	assert.NoError(err)
+	_, err = admin.Connect(ctx, &PeerRequest{Address: "10.0.0.1:7000"})
+	assert.Equal(codes.AlreadyExists, status.Code(err))
+	assert.NoError(m.Connected("10.0.0.1:5555", Handshake{Nonce: 1}, Inbound))
+	assert.NoError(m.Connected("10.0.0.2:5555", Handshake{Nonce: 2}, Inbound))
+
+	list, err := admin.ListPeers(ctx, &ListPeersRequest{})
+	assert.NoError(err)
+	assert.Len(list.Peers, 3)
+	assert.Equal("10.0.0.1:7000", list.Peers[1].Address)
+	assert.Equal("0.1.0", list.Peers[1].Version)
+	assert.Equal(uint64(10), list.Peers[1].Height)
+
+	_, err = admin.Disconnect(ctx, &PeerRequest{Address: "10.0.0.2:5555"})
+	assert.NoError(err)
+	assert.True(disconnected["10.0.0.2:5555"])
+	_, err = admin.Disconnect(ctx, &PeerRequest{Address: "10.0.0.2:5555"})
+	assert.Equal(codes.NotFound, status.Code(err))
+
+	// the ban disconnects the peers of the host, whatever the port
+	ban, err := admin.Ban(ctx, &BanRequest{Host: "10.0.0.1:7000", Reason: "spam"})
+	assert.NoError(err)
+	assert.Equal(2, ban.Disconnected)
+	assert.Empty(m.Peers())
+	assert.Equal("spam", m.Bans()[0].Reason)
+
+	_, err = admin.Connect(ctx, &PeerRequest{Address: "10.0.0.1:7000"})
+	assert.Equal(codes.FailedPrecondition, status.Code(err))
+
+	unban, err := admin.Unban(ctx, &UnbanRequest{})
+	assert.NoError(err)
+	assert.Equal(1, unban.Unbanned)
+
+	_, err = admin.GetRoutingTable(ctx, &RoutingTableRequest{})
+	assert.Equal(codes.Unavailable, status.Code(err))
+}
+
+// TestScoreDecay tests that the misbehaviour scores are halved every
+// scoreHalfLife
+func TestScoreDecay(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+	s := score{value: 80, updated: now}
+	assert.InDelta(80, s.at(now), 0.001)
+	assert.InDelta(40, s.at(now.Add(scoreHalfLife)), 0.001)
+	assert.InDelta(20, s.at(now.Add(2*scoreHalfLife)), 0.001)
+}
+
+// TestAddrExchange tests that the Manager answers GetAddr messages once per
+// getAddrInterval, and rate limits the addresses it takes from its peers
+func TestAddrExchange(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{MaxInbound: 8})
+	defer cleanup()
+
+	m.book.Good("10.0.0.9:7000", protocol.FullNode)
+	assert.NoError(m.Connected("10.0.0.1:5555", Handshake{Nonce: 1, ListenAddr: "10.0.0.1:7000"}, Inbound))
+	assert.NoError(m.Connected("10.1.0.1:7000", Handshake{Nonce: 2}, Outbound))
+
+	getAddr := message.New(topics.GetAddr, nil)
+	bufs, err := m.ProcessGetAddr("10.0.0.1:5555", getAddr)
+	assert.NoError(err)
+	assert.Len(bufs, 1)
+
+	msg, err := message.Unmarshal(&bufs[0])
+	assert.NoError(err)
+	addrs := msg.Payload().(message.Addr).Addrs
+	// the outbound peer, and the good address. The inbound peer was only
+	// learned
+	assert.Len(addrs, 2)
+
+	bufs, err = m.ProcessGetAddr("10.0.0.1:5555", getAddr)
+	assert.NoError(err)
+	assert.Empty(bufs)
+
+	// the outbound peer was asked for addresses
+	now := time.Now()
+	addrs = make([]message.NetAddress, 0, maxAddrsPerSource)
+	for i := 0; i < maxAddrsPerSource; i++ {
+		addrs = append(addrs, message.NetAddress{Timestamp: now.Unix(), IP: net.IPv4(10, byte(10+i), 0, 1), Port: 7000})
+	}
+	_, err = m.ProcessAddr("10.1.0.1:7000", message.New(topics.Addr, message.Addr{Addrs: addrs}))
+	assert.NoError(err)
+	assert.Len(m.book.Addrs(), 3+maxAddrsPerSource)
+
+	// the inbound one was not
+	_, err = m.ProcessAddr("10.0.0.1:5555", message.New(topics.Addr, message.Addr{Addrs: []message.NetAddress{
+		{Timestamp: now.Unix(), IP: net.ParseIP("10.200.0.1"), Port: 7000},
+	}}))
+	assert.NoError(err)
+	assert.Len(m.book.Addrs(), 3+maxAddrsPerSource)
+}
+
+// TestStopTwice tests that the Manager can be stopped several times
+func TestStopTwice(t *testing.T) {
+	assert := assert.New(t)
+	m, cleanup := newTestManager(t, Config{})
+	defer cleanup()
+
+	m.Start(func(string) error { return nil }, nil)
+	assert.NoError(m.Stop())
+	assert.NotPanics(func() {
+		assert.NoError(m.Stop())
+	})
+}
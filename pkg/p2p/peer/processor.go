@@ -15,36 +15,50 @@ import (
 // to the MessageProcessor, in order to process messages from the network.
 type ProcessorFunc func(message.Message) ([]bytes.Buffer, error)
 
+// PeerProcessorFunc is a ProcessorFunc which also gets the address of the
+// peer which sent the message.
+type PeerProcessorFunc func(srcPeer string, msg message.Message) ([]bytes.Buffer, error)
+
 // MessageProcessor is connected to all of the processing units that are tied to the peer.
 // It sends an incoming message in the right direction, according to its topic.
 type MessageProcessor struct {
 	dupeMap    *dupemap.DupeMap
-	processors map[topics.Topic]ProcessorFunc
+	processors map[topics.Topic]PeerProcessorFunc
 }
 
 // NewMessageProcessor returns an initialized MessageProcessor.
 func NewMessageProcessor(bus eventbus.Broker) *MessageProcessor {
 	return &MessageProcessor{
 		dupeMap:    dupemap.Launch(bus),
-		processors: make(map[topics.Topic]ProcessorFunc),
+		processors: make(map[topics.Topic]PeerProcessorFunc),
 	}
 }
 
 // Register a method to a certain topic. This method will be called when a message
 // of the given topic is received.
 func (m *MessageProcessor) Register(topic topics.Topic, fn ProcessorFunc) {
+	m.processors[topic] = func(_ string, msg message.Message) ([]bytes.Buffer, error) {
+		return fn(msg)
+	}
+}
+
+// RegisterPeer registers a method to a certain topic, like Register, for the
+// processing units which need to know the sender of the message.
+func (m *MessageProcessor) RegisterPeer(topic topics.Topic, fn PeerProcessorFunc) {
 	m.processors[topic] = fn
 }
 
-// Collect a message from the network. The message is unmarshaled and passed down
-// to the processing function.
-func (m *MessageProcessor) Collect(packet []byte, respChan chan<- bytes.Buffer) error {
+// Collect a message sent by srcPeer from the network. The message is
+// unmarshaled and passed down to the processing function. Malformed messages
+// are returned as a *MisbehaviourError, as well as the processing errors
+// which the processing function reports as such
+func (m *MessageProcessor) Collect(srcPeer string, packet []byte, respChan chan<- bytes.Buffer) error {
 	b := bytes.NewBuffer(packet)
 	msg, err := message.Unmarshal(b)
 	if err != nil {
-		return err
+		return &MisbehaviourError{Misbehaviour: MalformedMessage, Err: err}
 	}
-	return m.process(msg, respChan)
+	return m.process(srcPeer, msg, respChan)
 }
 
 // CanRoute determines whether or not a message needs to be filtered by the
@@ -64,7 +78,7 @@ func (m *MessageProcessor) CanRoute(topic topics.Topic) bool {
 	return false
 }
 
-func (m *MessageProcessor) process(msg message.Message, respChan chan<- bytes.Buffer) error {
+func (m *MessageProcessor) process(srcPeer string, msg message.Message, respChan chan<- bytes.Buffer) error {
 	category := msg.Category()
 	if m.CanRoute(category) {
 		if !m.dupeMap.CanFwd(bytes.NewBuffer(msg.Id())) {
@@ -78,7 +92,7 @@ func (m *MessageProcessor) process(msg message.Message, respChan chan<- bytes.Bu
 		return nil
 	}
 
-	bufs, err := processFn(msg)
+	bufs, err := processFn(srcPeer, msg)
 	if err != nil {
 		return err
 	}
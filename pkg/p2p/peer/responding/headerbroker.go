@@ -0,0 +1,82 @@
+package responding
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+)
+
+// HeaderBroker is a processing unit which handles GetHeaders messages, sent by
+// the light nodes to sync the chain.
+type HeaderBroker struct {
+	db database.DB
+}
+
+// NewHeaderBroker will return an initialized HeaderBroker.
+func NewHeaderBroker(db database.DB) *HeaderBroker {
+	return &HeaderBroker{
+		db: db,
+	}
+}
+
+// ProvideHeaders takes a GetHeaders wire message, and returns a Headers
+// message with up to message.MaxHeaders headers which follow the provided
+// locator.
+func (h *HeaderBroker) ProvideHeaders(m message.Message) ([]bytes.Buffer, error) {
+	msg := m.Payload().(message.GetBlocks)
+	if len(msg.Locators) == 0 {
+		return nil, errors.New("empty locators array")
+	}
+
+	headers := make([]*block.Header, 0)
+	err := h.db.View(func(t database.Transaction) error {
+		locator, err := t.FetchBlockHeader(msg.Locators[0])
+		if err != nil {
+			return err
+		}
+
+		for height := locator.Height + 1; len(headers) < message.MaxHeaders; height++ {
+			hash, err := t.FetchBlockHashByHeight(height)
+			if err == database.ErrBlockNotFound {
+				// we passed the tip of the chain
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			header, err := t.FetchBlockHeader(hash)
+			if err != nil {
+				return err
+			}
+
+			headers = append(headers, header)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	buf := new(bytes.Buffer)
+	if err := (&message.Headers{Headers: headers}).Encode(buf); err != nil {
+		return nil, err
+	}
+
+	if err := topics.Prepend(buf, topics.Headers); err != nil {
+		return nil, err
+	}
+
+	return []bytes.Buffer{*buf}, nil
+}
@@ -0,0 +1,47 @@
+package responding_test
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/database/lite"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test the behavior of the header broker, upon receiving a GetHeaders message.
+func TestProvideHeaders(t *testing.T) {
+	assert := assert.New(t)
+	_, db := lite.CreateDBConnection()
+	defer func() {
+		_ = db.Close()
+	}()
+
+	hashes, blocks := generateBlocks(5)
+	assert.NoError(storeBlocks(db, blocks))
+
+	headerBroker := responding.NewHeaderBroker(db)
+
+	// Ask for the headers following the second block
+	getHeaders := &message.GetBlocks{Locators: [][]byte{hashes[1]}}
+	bufs, err := headerBroker.ProvideHeaders(message.New(topics.GetHeaders, *getHeaders))
+	assert.NoError(err)
+	assert.Len(bufs, 1)
+
+	topic, _ := topics.Extract(&bufs[0])
+	assert.Equal(topics.Headers, topic)
+
+	headers := &message.Headers{}
+	assert.NoError(headers.Decode(&bufs[0]))
+	assert.Len(headers.Headers, 3)
+	for i, h := range headers.Headers {
+		assert.True(blocks[i+2].Header.Equals(h))
+	}
+
+	// Nothing is sent to the peers at the tip
+	getHeaders = &message.GetBlocks{Locators: [][]byte{hashes[4]}}
+	bufs, err = headerBroker.ProvideHeaders(message.New(topics.GetHeaders, *getHeaders))
+	assert.NoError(err)
+	assert.Empty(bufs)
+}
@@ -0,0 +1,79 @@
+package responding
+
+import (
+	"bytes"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+)
+
+// MaxInvMempoolPage is the maximum number of txids advertised in a single
+// inventory message, well below the limit enforced by Inv.Decode
+const MaxInvMempoolPage = 1000
+
+// MempoolBroker is a processing unit which handles MemPool messages, sent by
+// newly connected peers to learn about the pending txs
+type MempoolBroker struct {
+	rpcBus *rpcbus.RPCBus
+}
+
+// NewMempoolBroker returns an initialized MempoolBroker.
+func NewMempoolBroker(rpcBus *rpcbus.RPCBus) *MempoolBroker {
+	return &MempoolBroker{
+		rpcBus: rpcBus,
+	}
+}
+
+// AdvertiseMempool answers a MemPool message with inventory messages of up to
+// config.Mempool.MaxInvItems txids, sorted by fee from highest to lowest and
+// paged by MaxInvMempoolPage. The requesting peer then fetches the txs it
+// misses through its DataRequestor.
+func (b *MempoolBroker) AdvertiseMempool(m message.Message) ([]bytes.Buffer, error) {
+	maxItems := int(config.Get().Mempool.MaxInvItems)
+	if maxItems == 0 {
+		// topics.MemPool handling is disabled
+		return nil, nil
+	}
+
+	// an empty txid retrieves all the verified txs, sorted by fee
+	txs, err := GetMempoolTxs(b.rpcBus, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) > maxItems {
+		txs = txs[:maxItems]
+	}
+
+	bufs := make([]bytes.Buffer, 0, len(txs)/MaxInvMempoolPage+1)
+	inv := &message.Inv{}
+	for _, tx := range txs {
+		txid, err := tx.CalculateHash()
+		if err != nil {
+			return nil, err
+		}
+
+		inv.AddItem(message.InvTypeMempoolTx, txid)
+		if len(inv.InvList) == MaxInvMempoolPage {
+			buf, err := marshalInv(inv)
+			if err != nil {
+				return nil, err
+			}
+
+			bufs = append(bufs, buf)
+			inv = &message.Inv{}
+		}
+	}
+
+	if inv.InvList != nil {
+		buf, err := marshalInv(inv)
+		if err != nil {
+			return nil, err
+		}
+
+		bufs = append(bufs, buf)
+	}
+
+	return bufs, nil
+}
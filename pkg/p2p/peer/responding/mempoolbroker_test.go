@@ -0,0 +1,60 @@
+package responding_test
+
+import (
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/ipc/transactions"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/responding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/rpcbus"
+	assert "github.com/stretchr/testify/require"
+)
+
+// Test that the mempool broker advertises up to MaxInvItems txids, in the
+// mempool order and paged by MaxInvMempoolPage.
+func TestAdvertiseMempool(t *testing.T) {
+	assert := assert.New(t)
+
+	r := config.Registry{}
+	r.Mempool.MaxInvItems = 2200
+	config.Mock(&r)
+
+	txs := make([]transactions.ContractCall, 2500)
+	for i := range txs {
+		txs[i] = transactions.RandTx()
+	}
+
+	rpcBus := rpcbus.New()
+	c := make(chan rpcbus.Request, 1)
+	assert.NoError(rpcBus.Register(topics.GetMempoolTxs, c))
+	go func() {
+		r := <-c
+		r.RespChan <- rpcbus.NewResponse(txs, nil)
+	}()
+
+	mb := responding.NewMempoolBroker(rpcBus)
+	bufs, err := mb.AdvertiseMempool(message.New(topics.MemPool, nil))
+	assert.NoError(err)
+	assert.Len(bufs, 3)
+
+	i := 0
+	for _, buf := range bufs {
+		topic, _ := topics.Extract(&buf)
+		assert.Equal(topics.Inv, topic)
+
+		inv := &message.Inv{}
+		assert.NoError(inv.Decode(&buf))
+		assert.True(len(inv.InvList) <= responding.MaxInvMempoolPage)
+
+		for _, item := range inv.InvList {
+			txid, _ := txs[i].CalculateHash()
+			assert.Equal(message.InvTypeMempoolTx, item.Type)
+			assert.Equal(txid, item.Hash)
+			i++
+		}
+	}
+
+	assert.Equal(2200, i)
+}
@@ -0,0 +1,83 @@
+package peer
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+	"time"
+)
+
+// PeerStatus is the state of a connected peer, as observed by the node
+type PeerStatus struct {
+	// Height is the highest block height the peer sent. It is zero until
+	// the peer sends a block
+	Height uint64 `json:"height"`
+	// Latency is the round trip time of the last keepalive ping answered by
+	// the peer. It is zero until one is answered
+	Latency   time.Duration `json:"latency"`
+	Bandwidth PeerBandwidth `json:"bandwidth"`
+}
+
+// GetPeerStatus returns the status of the peer connected at addr
+func GetPeerStatus(addr string) (PeerStatus, bool) {
+	bandwidth.lock.Lock()
+	m, ok := bandwidth.peers[addr]
+	bandwidth.lock.Unlock()
+	if !ok {
+		return PeerStatus{}, false
+	}
+
+	return PeerStatus{
+		Height:    atomic.LoadUint64(&m.height),
+		Latency:   time.Duration(atomic.LoadInt64(&m.latency)),
+		Bandwidth: m.stats(),
+	}, true
+}
+
+// Disconnect closes the connection to the peer at addr. It returns false if
+// the peer is not connected
+func Disconnect(addr string) bool {
+	bandwidth.lock.Lock()
+	m, ok := bandwidth.peers[addr]
+	bandwidth.lock.Unlock()
+	if !ok || m.conn == nil {
+		return false
+	}
+
+	_ = m.conn.Close()
+	return true
+}
+
+// pinged records the time a keepalive ping was sent to the peer
+func (c *Connection) pinged() {
+	if c.meter != nil {
+		atomic.StoreInt64(&c.meter.pingSent, time.Now().UnixNano())
+	}
+}
+
+// ponged measures the latency of the peer, once it answered a ping
+func (c *Connection) ponged() {
+	if c.meter == nil {
+		return
+	}
+
+	sent := atomic.SwapInt64(&c.meter.pingSent, 0)
+	if sent != 0 {
+		atomic.StoreInt64(&c.meter.latency, time.Now().UnixNano()-sent)
+	}
+}
+
+// sawBlock records the height of a block message sent by the peer. The
+// height follows the topic and the version of the header
+func (c *Connection) sawBlock(message []byte) {
+	if c.meter == nil || len(message) < 10 {
+		return
+	}
+
+	height := binary.LittleEndian.Uint64(message[2:10])
+	for {
+		known := atomic.LoadUint64(&c.meter.height)
+		if height <= known || atomic.CompareAndSwapUint64(&c.meter.height, known, height) {
+			return
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package peer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+)
+
+// ErrEncryptionRequired is returned by the handshake when the peer does not
+// support the encrypted transport, and the node requires it
+var ErrEncryptionRequired = errors.New("peer does not support the encrypted transport")
+
+// ErrServicesMismatch is returned by the handshake when the services
+// confirmed through the encrypted transport differ from the ones exchanged in
+// the version messages, which were then tampered with
+var ErrServicesMismatch = errors.New("services exchanged in the handshake were tampered with")
+
+// Identity is the persistent key pair identifying a node on the encrypted
+// transport. Its public key is what the peers authenticate
+type Identity struct {
+	key  ed25519.PrivateKey
+	cert tls.Certificate
+}
+
+// IdentityFile returns the path of the identity key, as configured or next to
+// the chain database
+func IdentityFile() string {
+	if path := config.Get().Network.Transport.IdentityKey; path != "" {
+		return path
+	}
+
+	return filepath.Join(filepath.Dir(config.Get().Database.Dir), "identity.key")
+}
+
+// LoadIdentity loads the identity key persisted at path. A new key is
+// generated and persisted if the file is missing
+func LoadIdentity(path string) (*Identity, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return newIdentity(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid identity key file")
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := k.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("identity key is not an ed25519 key")
+	}
+
+	return identityFromKey(key)
+}
+
+func newIdentity(path string) (*Identity, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+
+	return identityFromKey(key)
+}
+
+// identityFromKey creates the self-signed certificate the node presents in
+// the TLS handshakes. The certificate only carries the identity key, so it is
+// created anew on each start
+func identityFromKey(key ed25519.PrivateKey) (*Identity, error) {
+	pub := key.Public().(ed25519.PublicKey)
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: hex.EncodeToString(pub)},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Identity{
+		key:  key,
+		cert: tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key},
+	}, nil
+}
+
+// PublicKey returns the public key identifying the node
+func (id *Identity) PublicKey() ed25519.PublicKey {
+	return id.key.Public().(ed25519.PublicKey)
+}
+
+// transport is the encrypted transport setting of the node. A nil identity
+// disables the encryption
+var transport struct {
+	identity *Identity
+	required bool
+}
+
+// SetTransport enables the encrypted transport with the peers supporting it,
+// authenticated by id. If required, the peers which do not support it are
+// refused. A nil id disables the encrypted transport
+func SetTransport(id *Identity, required bool) {
+	transport.identity = id
+	transport.required = required
+}
+
+// localServices returns the services this node advertises in its version
+// messages
+func localServices() protocol.ServiceFlag {
+	services := protocol.FullNode
+	if lightNode {
+		services = protocol.LightNode
+	}
+
+	if transport.identity != nil {
+		services |= protocol.Encrypted
+	}
+
+	if compression.threshold > 0 {
+		services |= protocol.Compressed
+	}
+
+	return services
+}
+
+// negotiateEncryption tells if a connection has to be upgraded to the
+// encrypted transport, given the services of the peer
+func negotiateEncryption(remote protocol.ServiceFlag) (bool, error) {
+	if transport.identity != nil && remote&protocol.Encrypted != 0 {
+		return true, nil
+	}
+
+	if transport.required {
+		return false, ErrEncryptionRequired
+	}
+
+	return false, nil
+}
+
+// secure upgrades the connection to TLS 1.3 if both ends support it. It runs
+// once the Version/VerAck exchange is completed, with the node dialing the
+// connection acting as the TLS client.
+// As the version messages are exchanged in plaintext, both ends confirm them
+// through the encrypted transport, so that the Encrypted service cannot be
+// stripped from one end only. A connection left in plaintext while either end
+// supports the encryption is warned about, as it might have been stripped
+// from both
+func (c *Connection) secure(dialer bool) error {
+	encrypt, err := negotiateEncryption(c.remoteServices)
+	if err != nil {
+		return err
+	}
+
+	if !encrypt {
+		if transport.identity != nil || c.remoteServices&protocol.Encrypted != 0 {
+			l.WithField("address", c.Addr()).
+				WithField("services", c.remoteServices).
+				Warnln("connection to a peer supporting the encryption is not encrypted")
+		}
+		return nil
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{transport.identity.cert},
+		MinVersion:   tls.VersionTLS13,
+		// the peers present self-signed certificates of their identity key,
+		// which verifyIdentity checks instead of a chain of trust
+		InsecureSkipVerify:    true,
+		ClientAuth:            tls.RequireAnyClientCert,
+		VerifyPeerCertificate: verifyIdentity,
+	}
+
+	var conn *tls.Conn
+	if dialer {
+		conn = tls.Client(c.Conn, cfg)
+	} else {
+		conn = tls.Server(c.Conn, cfg)
+	}
+
+	if timeout := time.Duration(config.Get().Timeout.TimeoutReadWrite) * time.Second; timeout > 0 {
+		_ = conn.SetDeadline(time.Now().Add(timeout))
+		defer func() {
+			_ = conn.SetDeadline(time.Time{})
+		}()
+	}
+
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+
+	if err := confirmServices(conn, dialer, localServices(), c.remoteServices); err != nil {
+		return err
+	}
+
+	c.remoteIdentity = conn.ConnectionState().PeerCertificates[0].PublicKey.(ed25519.PublicKey)
+	c.Conn = conn
+	return nil
+}
+
+// confirmServices exchanges the services each end sent and received in the
+// version messages through the encrypted conn, and checks that both ends saw
+// the same ones. The dialer goes first
+func confirmServices(conn io.ReadWriter, dialer bool, local, remote protocol.ServiceFlag) error {
+	send := func() error {
+		buf := new(bytes.Buffer)
+		if err := encoding.WriteUint64LE(buf, uint64(local)); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(buf, uint64(remote)); err != nil {
+			return err
+		}
+
+		_, err := conn.Write(buf.Bytes())
+		return err
+	}
+
+	receive := func() error {
+		b := make([]byte, 16)
+		if _, err := io.ReadFull(conn, b); err != nil {
+			return err
+		}
+
+		var sent, received uint64
+		buf := bytes.NewBuffer(b)
+		if err := encoding.ReadUint64LE(buf, &sent); err != nil {
+			return err
+		}
+
+		if err := encoding.ReadUint64LE(buf, &received); err != nil {
+			return err
+		}
+
+		// what the peer sent is what this end received, and the other way
+		// around
+		if protocol.ServiceFlag(sent) != remote || protocol.ServiceFlag(received) != local {
+			return ErrServicesMismatch
+		}
+
+		return nil
+	}
+
+	if dialer {
+		if err := send(); err != nil {
+			return err
+		}
+		return receive()
+	}
+
+	if err := receive(); err != nil {
+		return err
+	}
+	return send()
+}
+
+// verifyIdentity checks that the peer certificate is a valid self-signed
+// certificate of an ed25519 key
+func verifyIdentity(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return errors.New("expected a single identity certificate")
+	}
+
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cert.PublicKey.(ed25519.PublicKey); !ok {
+		return errors.New("identity certificate is not an ed25519 one")
+	}
+
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// RemoteIdentity returns the identity key of the peer. It is nil if the
+// connection is not encrypted
+func (c *Connection) RemoteIdentity() ed25519.PublicKey {
+	return c.remoteIdentity
+}
@@ -0,0 +1,163 @@
+package peer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfg "github.com/dusk-network/dusk-blockchain/pkg/config"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/peer/dupemap"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/util/nativeutils/eventbus"
+)
+
+// TestLoadIdentity ensures the identity key is generated once, and loaded on
+// the next starts
+func TestLoadIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "identity.key")
+	id, err := LoadIdentity(path)
+	require.NoError(t, err)
+
+	loaded, err := LoadIdentity(path)
+	require.NoError(t, err)
+	require.Equal(t, id.PublicKey(), loaded.PublicKey())
+}
+
+// TestEncryptedHandshake ensures the connections are upgraded to the
+// encrypted transport when both ends support it, and authenticate each other
+func TestEncryptedHandshake(t *testing.T) {
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	r, err := cfg.LoadFromFile(cwd + "/../../../dusk.toml")
+	require.NoError(t, err)
+	cfg.Mock(&r)
+
+	dir, err := ioutil.TempDir("", "identity")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	id, err := LoadIdentity(filepath.Join(dir, "identity.key"))
+	require.NoError(t, err)
+	SetTransport(id, true)
+	defer SetTransport(nil, false)
+
+	eb := eventbus.New()
+	factory := NewReaderFactory(NewMessageProcessor(eb))
+	client, srv := net.Pipe()
+
+	accepted := make(chan *Reader, 1)
+	go func() {
+		peerReader, err := factory.SpawnReader(srv, protocol.NewGossip(protocol.TestNet), dupemap.NewDupeMap(0), make(chan bytes.Buffer, 100))
+		if err != nil {
+			panic(err)
+		}
+
+		if err := peerReader.Handshake(); err != nil {
+			panic(err)
+		}
+		accepted <- peerReader
+	}()
+
+	pw := NewWriter(client, protocol.NewGossip(protocol.TestNet), eb)
+	defer func() {
+		_ = pw.Conn.Close()
+	}()
+	require.NoError(t, pw.Handshake())
+	pr := <-accepted
+
+	// both ends run in this process, with the same identity
+	require.Equal(t, id.PublicKey(), pw.RemoteIdentity())
+	require.Equal(t, id.PublicKey(), pr.RemoteIdentity())
+
+	// the messages now go through the encrypted transport
+	go func() {
+		_ = pw.keepAlive()
+	}()
+	msg, err := pr.ReadMessage()
+	require.NoError(t, err)
+	require.NotEmpty(t, msg)
+}
+
+// TestNegotiateEncryption ensures the peers without encryption are refused
+// only if the encryption is required
+func TestNegotiateEncryption(t *testing.T) {
+	defer SetTransport(nil, false)
+
+	encrypt, err := negotiateEncryption(protocol.FullNode | protocol.Encrypted)
+	require.NoError(t, err)
+	require.False(t, encrypt)
+
+	id, err := identityFromKey(newTestKey(t))
+	require.NoError(t, err)
+	SetTransport(id, false)
+	require.Equal(t, protocol.FullNode|protocol.Encrypted, localServices())
+
+	encrypt, err = negotiateEncryption(protocol.FullNode)
+	require.NoError(t, err)
+	require.False(t, encrypt)
+
+	encrypt, err = negotiateEncryption(protocol.FullNode | protocol.Encrypted)
+	require.NoError(t, err)
+	require.True(t, encrypt)
+
+	SetTransport(id, true)
+	_, err = negotiateEncryption(protocol.FullNode)
+	require.Equal(t, ErrEncryptionRequired, err)
+}
+
+// TestConfirmServices ensures the services are confirmed if both ends saw
+// the same ones, and refused if they were tampered with
+func TestConfirmServices(t *testing.T) {
+	full := protocol.FullNode | protocol.Encrypted
+
+	// confirm returns the error of the listener, and sets the one of the
+	// dialer
+	var dialerErr error
+	confirm := func(dialerRemote, listenerRemote protocol.ServiceFlag) error {
+		client, srv := net.Pipe()
+		defer func() {
+			_ = client.Close()
+		}()
+
+		res := make(chan error, 1)
+		go func() {
+			err := confirmServices(srv, false, full, listenerRemote)
+			// unblocks the dialer waiting for the confirmation
+			_ = srv.Close()
+			res <- err
+		}()
+
+		dialerErr = confirmServices(client, true, full, dialerRemote)
+		return <-res
+	}
+
+	require.NoError(t, confirm(full, full))
+	require.NoError(t, dialerErr)
+
+	// the Encrypted service of the dialer was stripped on its way to the
+	// listener
+	require.Equal(t, ErrServicesMismatch, confirm(full, protocol.FullNode))
+	require.Error(t, dialerErr)
+}
+
+func newTestKey(t *testing.T) ed25519.PrivateKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
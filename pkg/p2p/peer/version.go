@@ -2,17 +2,81 @@ package peer
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"sync/atomic"
 	"time"
 
+	"github.com/dusk-network/dusk-blockchain/pkg/config"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
 	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
 )
 
+// localNonce identifies this node in the version messages, so that
+// connections to itself and several connections to the same node can be
+// detected. It is generated on startup
+var localNonce = newNonce()
+
+// LocalNonce returns the nonce this node sends in its version messages
+func LocalNonce() uint64 {
+	return localNonce
+}
+
+func newNonce() uint64 {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// lightNode tells if this node runs in light mode, in which case it advertises
+// the LightNode service instead of the FullNode one
+var lightNode bool
+
+// SetLightNode makes the node advertise the LightNode service, so that its
+// peers only send it the block announcements
+func SetLightNode(light bool) {
+	lightNode = light
+}
+
+// localHeight is the height of the chain of this node, sent in the version
+// messages
+var localHeight uint64
+
+// SetLocalHeight sets the height of the chain of this node, which the peers
+// connecting from now on are told in the version messages
+func SetLocalHeight(height uint64) {
+	atomic.StoreUint64(&localHeight, height)
+}
+
+// listenPort returns the port this node accepts connections on, or zero if it
+// is not configured
+func listenPort() uint16 {
+	port, err := strconv.ParseUint(config.Get().Network.Port, 10, 16)
+	if err != nil {
+		return 0
+	}
+
+	return uint16(port)
+}
+
 // VersionMessage is a version message on the dusk wire protocol.
 type VersionMessage struct {
 	Version   *protocol.Version
 	Timestamp int64
 	Services  protocol.ServiceFlag
+	// Nonce identifies the sending node. It is zero for the nodes which do
+	// not send it
+	Nonce uint64
+	// Port is the port the sending node accepts connections on. It is zero
+	// for the nodes which do not send it
+	Port uint16
+	// Height is the height of the chain of the sending node. It is zero for
+	// the nodes which do not send it
+	Height uint64
 }
 
 func newVersionMessageBuffer(v *protocol.Version, services protocol.ServiceFlag) (*bytes.Buffer, error) {
@@ -29,6 +93,18 @@ func newVersionMessageBuffer(v *protocol.Version, services protocol.ServiceFlag)
 		return nil, err
 	}
 
+	if err := encoding.WriteUint64LE(buffer, localNonce); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint16LE(buffer, listenPort()); err != nil {
+		return nil, err
+	}
+
+	if err := encoding.WriteUint64LE(buffer, atomic.LoadUint64(&localHeight)); err != nil {
+		return nil, err
+	}
+
 	return buffer, nil
 }
 
@@ -53,5 +129,26 @@ func decodeVersionMessage(r *bytes.Buffer) (*VersionMessage, error) {
 	}
 
 	versionMessage.Services = protocol.ServiceFlag(services)
+
+	// the nonce, the port and the height were appended later on, so they are
+	// optional
+	if r.Len() >= 8 {
+		if err := encoding.ReadUint64LE(r, &versionMessage.Nonce); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Len() >= 2 {
+		if err := encoding.ReadUint16LE(r, &versionMessage.Port); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Len() >= 8 {
+		if err := encoding.ReadUint64LE(r, &versionMessage.Height); err != nil {
+			return nil, err
+		}
+	}
+
 	return versionMessage, nil
 }
@@ -0,0 +1,140 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+)
+
+// MaxAddrs is the maximum amount of addresses in an Addr message
+const MaxAddrs = 1000
+
+// NetAddress is the address of a node, as shared in Addr messages
+type NetAddress struct {
+	// Timestamp is the last time the address was known to be reachable, in
+	// seconds since the epoch
+	Timestamp int64
+	Services  protocol.ServiceFlag
+	IP        net.IP
+	Port      uint16
+}
+
+// String returns the address in the host:port form
+func (a NetAddress) String() string {
+	return net.JoinHostPort(a.IP.String(), strconv.Itoa(int(a.Port)))
+}
+
+// Addr defines an addr message on the Dusk wire protocol. It is sent in
+// response to a GetAddr message, and carries addresses of nodes the sender
+// could connect to.
+type Addr struct {
+	Addrs []NetAddress
+}
+
+// Copy an Addr message.
+// Implements the payload.Safe interface.
+func (a Addr) Copy() payload.Safe {
+	addrs := make([]NetAddress, len(a.Addrs))
+	for i, addr := range a.Addrs {
+		addrs[i] = addr
+		addrs[i].IP = make(net.IP, len(addr.IP))
+		copy(addrs[i].IP, addr.IP)
+	}
+
+	return Addr{addrs}
+}
+
+// Encode an Addr struct and write it to w.
+func (a *Addr) Encode(w *bytes.Buffer) error {
+	if len(a.Addrs) > MaxAddrs {
+		return errors.New("too many addresses in Addr message")
+	}
+
+	if err := encoding.WriteVarInt(w, uint64(len(a.Addrs))); err != nil {
+		return err
+	}
+
+	for _, addr := range a.Addrs {
+		if err := encoding.WriteUint64LE(w, uint64(addr.Timestamp)); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint64LE(w, uint64(addr.Services)); err != nil {
+			return err
+		}
+
+		ip := addr.IP.To16()
+		if ip == nil {
+			return errors.New("invalid IP in Addr message")
+		}
+
+		if _, err := w.Write(ip); err != nil {
+			return err
+		}
+
+		if err := encoding.WriteUint16LE(w, addr.Port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode an Addr struct from r into a.
+func (a *Addr) Decode(r *bytes.Buffer) error {
+	lenAddrs, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+
+	if lenAddrs > MaxAddrs {
+		return errors.New("too many addresses in Addr message")
+	}
+
+	a.Addrs = make([]NetAddress, lenAddrs)
+	for i := range a.Addrs {
+		var timestamp, services uint64
+		if err := encoding.ReadUint64LE(r, &timestamp); err != nil {
+			return err
+		}
+
+		if err := encoding.ReadUint64LE(r, &services); err != nil {
+			return err
+		}
+
+		ip := make(net.IP, net.IPv6len)
+		if _, err := io.ReadFull(r, ip); err != nil {
+			return err
+		}
+
+		a.Addrs[i] = NetAddress{
+			Timestamp: int64(timestamp),
+			Services:  protocol.ServiceFlag(services),
+			IP:        ip,
+		}
+
+		if err := encoding.ReadUint16LE(r, &a.Addrs[i].Port); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalAddrMessage unmarshals an Addr message into a
+// SerializableMessage.
+func UnmarshalAddrMessage(r *bytes.Buffer, m SerializableMessage) error {
+	a := &Addr{}
+	if err := a.Decode(r); err != nil {
+		return err
+	}
+
+	m.SetPayload(*a)
+	return nil
+}
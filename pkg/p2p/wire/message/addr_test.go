@@ -0,0 +1,36 @@
+package message_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/protocol"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeAddr(t *testing.T) {
+	addr := message.Addr{Addrs: []message.NetAddress{
+		{Timestamp: 1600000000, Services: protocol.FullNode, IP: net.ParseIP("10.0.0.1"), Port: 7000},
+		{Timestamp: 1600000001, IP: net.ParseIP("2001:db8::1"), Port: 7100},
+	}}
+
+	buf, err := message.Marshal(message.New(topics.Addr, addr))
+	assert.NoError(t, err)
+
+	msg, err := message.Unmarshal(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, addr, msg.Payload().(message.Addr))
+	assert.Equal(t, "[2001:db8::1]:7100", addr.Addrs[1].String())
+}
+
+func TestDecodeTooManyAddrs(t *testing.T) {
+	addr := &message.Addr{Addrs: make([]message.NetAddress, message.MaxAddrs+1)}
+	assert.Error(t, addr.Encode(new(bytes.Buffer)))
+
+	buf := new(bytes.Buffer)
+	buf.Write([]byte{0xfd, 0xe9, 0x03})
+	assert.Error(t, addr.Decode(buf))
+}
@@ -0,0 +1,84 @@
+package message
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/data/block"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/encoding"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message/payload"
+)
+
+// MaxHeaders is the maximum amount of block headers in a Headers message
+const MaxHeaders = 500
+
+// Headers defines a headers message on the Dusk wire protocol. It is sent in
+// response to a GetHeaders message, and carries the headers (along with their
+// certificates) of the blocks following the locator, in ascending height.
+type Headers struct {
+	Headers []*block.Header
+}
+
+// Copy a Headers message.
+// Implements the payload.Safe interface.
+func (h Headers) Copy() payload.Safe {
+	headers := make([]*block.Header, len(h.Headers))
+	for i, header := range h.Headers {
+		headers[i] = header.Copy()
+	}
+
+	return Headers{headers}
+}
+
+// Encode a Headers struct and write it to w.
+func (h *Headers) Encode(w *bytes.Buffer) error {
+	if len(h.Headers) > MaxHeaders {
+		return errors.New("too many headers in Headers message")
+	}
+
+	if err := encoding.WriteVarInt(w, uint64(len(h.Headers))); err != nil {
+		return err
+	}
+
+	for _, header := range h.Headers {
+		if err := MarshalHeader(w, header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Decode a Headers struct from r into h.
+func (h *Headers) Decode(r *bytes.Buffer) error {
+	lenHeaders, err := encoding.ReadVarInt(r)
+	if err != nil {
+		return err
+	}
+
+	if lenHeaders > MaxHeaders {
+		return errors.New("too many headers in Headers message")
+	}
+
+	h.Headers = make([]*block.Header, lenHeaders)
+	for i := range h.Headers {
+		h.Headers[i] = block.NewHeader()
+		if err := UnmarshalHeader(r, h.Headers[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalHeadersMessage unmarshals a Headers message into a
+// SerializableMessage.
+func UnmarshalHeadersMessage(r *bytes.Buffer, m SerializableMessage) error {
+	h := &Headers{}
+	if err := h.Decode(r); err != nil {
+		return err
+	}
+
+	m.SetPayload(*h)
+	return nil
+}
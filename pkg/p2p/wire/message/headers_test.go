@@ -0,0 +1,40 @@
+package message_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dusk-network/dusk-blockchain/pkg/core/tests/helper"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/message"
+	"github.com/dusk-network/dusk-blockchain/pkg/p2p/wire/topics"
+	assert "github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeHeaders(t *testing.T) {
+	assert := assert.New(t)
+	headers := message.Headers{}
+	for i := uint64(0); i < 3; i++ {
+		headers.Headers = append(headers.Headers, helper.RandomBlock(200+i, 1).Header)
+	}
+
+	buf, err := message.Marshal(message.New(topics.Headers, headers))
+	assert.NoError(err)
+
+	msg, err := message.Unmarshal(&buf)
+	assert.NoError(err)
+
+	decoded := msg.Payload().(message.Headers)
+	assert.Len(decoded.Headers, 3)
+	for i, h := range decoded.Headers {
+		assert.True(headers.Headers[i].Equals(h))
+	}
+}
+
+func TestDecodeTooManyHeaders(t *testing.T) {
+	assert := assert.New(t)
+	buf := new(bytes.Buffer)
+	buf.Write([]byte{0xfd, 0xf5, 0x01})
+
+	headers := &message.Headers{}
+	assert.Error(headers.Decode(buf))
+}
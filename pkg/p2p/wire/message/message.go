@@ -230,7 +230,7 @@ func Unmarshal(b *bytes.Buffer) (Message, error) {
 	switch topic {
 	case topics.Block:
 		err = UnmarshalBlockMessage(b, msg)
-	case topics.GetBlocks:
+	case topics.GetBlocks, topics.GetHeaders:
 		err = UnmarshalGetBlocksMessage(b, msg)
 	case topics.Inv, topics.GetData:
 		err = UnmarshalInvMessage(b, msg)
@@ -246,6 +246,10 @@ func Unmarshal(b *bytes.Buffer) (Message, error) {
 		err = UnmarshalReductionMessage(b, msg)
 	case topics.Agreement:
 		err = UnmarshalAgreementMessage(b, msg)
+	case topics.Addr:
+		err = UnmarshalAddrMessage(b, msg)
+	case topics.Headers:
+		err = UnmarshalHeadersMessage(b, msg)
 	}
 
 	if err != nil {
@@ -332,6 +336,12 @@ func marshalMessage(topic topics.Topic, payload interface{}, buf *bytes.Buffer)
 	case topics.Agreement:
 		agreement := payload.(Agreement)
 		err = MarshalAgreement(buf, agreement)
+	case topics.Addr:
+		addr := payload.(Addr)
+		err = addr.Encode(buf)
+	case topics.Headers:
+		headers := payload.(Headers)
+		err = headers.Encode(buf)
 	default:
 		return fmt.Errorf("unsupported marshaling of message type: %v", topic.String())
 	}
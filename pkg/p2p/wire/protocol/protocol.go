@@ -19,8 +19,16 @@ const (
 	// FullNode indicates that a user is running the full node implementation of Dusk
 	FullNode ServiceFlag = 1
 
-	// LightNode indicates that a user is running a Dusk light node
-	// LightNode ServiceFlag = 2 // Not implemented
+	// LightNode indicates that a user is running a Dusk light node, which
+	// follows the chain through the block headers only
+	LightNode ServiceFlag = 2
+
+	// Encrypted indicates that the node can upgrade its connections to the
+	// encrypted and authenticated transport
+	Encrypted ServiceFlag = 4
+
+	// Compressed indicates that the node accepts compressed messages
+	Compressed ServiceFlag = 8
 )
 
 // NodeVer is the current node version.
@@ -81,6 +81,45 @@ const (
 
 	// Kadcast wire messaging
 	Kadcast
+
+	// Consensus introspection RPCBus topics
+	GetConsensusStatus
+	GetParticipation
+
+	// Stake lifecycle RPCBus topics
+	SendWithdrawStakeTx
+	SendWithdrawBidTx
+
+	// Mempool notifications
+	EvictedTx
+
+	// Fee estimation RPCBus topic
+	EstimateFee
+
+	// Mempool introspection RPCBus topic
+	GetVerificationStats
+
+	// Mempool tx lifecycle events
+	MempoolEvent
+
+	// Peer manager RPCBus topics
+	GetPeers
+	GetBans
+	ClearBans
+
+	// Peer address exchange topics
+	GetAddr
+	Addr
+
+	// Compressed wraps the compressed messages
+	Compressed
+	GetCompressionStats
+
+	// Light node topics
+	GetHeaders
+	Headers
+	GetCheckpoint
+	GetBandwidthStats
 )
 
 type topicBuf struct {
@@ -141,6 +180,25 @@ var Topics = [...]topicBuf{
 	{GetCandidate, *(bytes.NewBuffer([]byte{byte(GetCandidate)})), "getcandidate"},
 	{SyncProgress, *(bytes.NewBuffer([]byte{byte(SyncProgress)})), "syncprogress"},
 	{Kadcast, *(bytes.NewBuffer([]byte{byte(Kadcast)})), "kadcast"},
+	{GetConsensusStatus, *(bytes.NewBuffer([]byte{byte(GetConsensusStatus)})), "getconsensusstatus"},
+	{GetParticipation, *(bytes.NewBuffer([]byte{byte(GetParticipation)})), "getparticipation"},
+	{SendWithdrawStakeTx, *(bytes.NewBuffer([]byte{byte(SendWithdrawStakeTx)})), "sendwithdrawstaketx"},
+	{SendWithdrawBidTx, *(bytes.NewBuffer([]byte{byte(SendWithdrawBidTx)})), "sendwithdrawbidtx"},
+	{EvictedTx, *(bytes.NewBuffer([]byte{byte(EvictedTx)})), "evictedtx"},
+	{EstimateFee, *(bytes.NewBuffer([]byte{byte(EstimateFee)})), "estimatefee"},
+	{GetVerificationStats, *(bytes.NewBuffer([]byte{byte(GetVerificationStats)})), "getverificationstats"},
+	{MempoolEvent, *(bytes.NewBuffer([]byte{byte(MempoolEvent)})), "mempoolevent"},
+	{GetPeers, *(bytes.NewBuffer([]byte{byte(GetPeers)})), "getpeers"},
+	{GetBans, *(bytes.NewBuffer([]byte{byte(GetBans)})), "getbans"},
+	{ClearBans, *(bytes.NewBuffer([]byte{byte(ClearBans)})), "clearbans"},
+	{GetAddr, *(bytes.NewBuffer([]byte{byte(GetAddr)})), "getaddr"},
+	{Addr, *(bytes.NewBuffer([]byte{byte(Addr)})), "addr"},
+	{Compressed, *(bytes.NewBuffer([]byte{byte(Compressed)})), "compressed"},
+	{GetCompressionStats, *(bytes.NewBuffer([]byte{byte(GetCompressionStats)})), "getcompressionstats"},
+	{GetHeaders, *(bytes.NewBuffer([]byte{byte(GetHeaders)})), "getheaders"},
+	{Headers, *(bytes.NewBuffer([]byte{byte(Headers)})), "headers"},
+	{GetCheckpoint, *(bytes.NewBuffer([]byte{byte(GetCheckpoint)})), "getcheckpoint"},
+	{GetBandwidthStats, *(bytes.NewBuffer([]byte{byte(GetBandwidthStats)})), "getbandwidthstats"},
 }
 
 func checkConsistency(topics []topicBuf) {
@@ -164,6 +164,7 @@ func (n *NodeClient) resetConn(options ...grpc.DialOption) error {
 		options,
 		grpc.WithContextDialer(getDialer(n.proto)),
 		grpc.WithUnaryInterceptor(n.sessionHandler.Unary()),
+		grpc.WithStreamInterceptor(n.sessionHandler.Stream()),
 	)
 	// create the GRPC connection
 	conn, err := grpc.Dial(
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// JSONCodecName is the content-subtype of the gRPC calls whose messages are
+// encoded in JSON. Node services which are not (yet) part of dusk-protobuf
+// are served with this codec
+const JSONCodecName = "json"
+
+type jsonCodec struct{}
+
+// Marshal as defined by encoding.Codec
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal as defined by encoding.Codec
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Name as defined by encoding.Codec
+func (jsonCodec) Name() string {
+	return JSONCodecName
+}
+
+// JSONCallOption is the grpc.CallOption clients need in order to call the
+// services encoded in JSON
+func JSONCallOption() grpc.CallOption {
+	return grpc.CallContentSubtype(JSONCodecName)
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -105,6 +105,34 @@ func (ai *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
 	}
 }
 
+// Stream returns a StreamServerInterceptor running the same session check as
+// Unary, once at the opening of the stream
+func (ai *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tag := "Stream call " + info.FullMethod
+		log.Tracef("%s", tag)
+
+		vctx, err := ai.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authorizedStream{ServerStream: ss, ctx: vctx})
+	}
+}
+
+// authorizedStream is a grpc.ServerStream carrying the context of the
+// authorized session
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the authorized session
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
 func (ai *AuthInterceptor) authorize(ctx context.Context, method string) (context.Context, error) {
 	if ai.openMethods.Has([]byte(method)) {
 		return ctx, nil
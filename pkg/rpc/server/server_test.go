@@ -59,6 +59,7 @@ func TestMain(m *testing.M) {
 		grpc.WithInsecure(),
 		grpc.WithContextDialer(getDialer("unix")),
 		grpc.WithUnaryInterceptor(interceptor.Unary()),
+		grpc.WithStreamInterceptor(interceptor.Stream()),
 	)
 
 	if err != nil {
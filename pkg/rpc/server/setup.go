@@ -65,8 +65,8 @@ func SetupGRPC(conf Setup) (*grpc.Server, error) {
 		// instantiate the auth service and the interceptor
 		auth, authInterceptor := NewAuth(jwtMan)
 
-		//serverOpt = append(serverOpt, grpc.StreamInterceptor(streamInterceptor))
 		serverOpt = append(serverOpt, grpc.UnaryInterceptor(authInterceptor.Unary()))
+		serverOpt = append(serverOpt, grpc.StreamInterceptor(authInterceptor.Stream()))
 		grpcServer := grpc.NewServer(serverOpt...)
 
 		// hooking up the Auth service